@@ -1,7 +1,6 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 
 	"github.com/turbot/terraform-provider-pipes/pipes"
@@ -9,8 +8,7 @@ import (
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return pipes.Provider()
-		},
+		// The server of the provider adds the list resources to the server of the SDK
+		GRPCProviderFunc: pipes.ProviderServer,
 	})
 }
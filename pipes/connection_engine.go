@@ -0,0 +1,367 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/pipes-sdk-go"
+)
+
+// connectionKey:: Where a connection lives, i.e. its handle and the tenant, organization or workspace it belongs to
+type connectionKey struct {
+	tenant       string
+	organization string
+	workspace    string
+	handle       string
+}
+
+// connectionScope:: The API adapter of the connections at one scope (tenant, organization or workspace). The connection
+// engine reaches the API, and builds and parses the IDs of the connections, through it.
+type connectionScope struct {
+	// The name of the resource, errors are prefixed with it
+	name string
+	// The schema of the resource, attributes which are not part of it are never set
+	attributes map[string]*schema.Schema
+	// The attributes named differently at this scope, e.g. organization_id for the identity_id of an organization connection
+	renames map[string]string
+	// How the plugin and config of the connection are read from and set on the resource data
+	config connectionConfig
+	// Whether a connection which no longer exists is removed from state with a warning, rather than failing the read
+	removeNotFound bool
+
+	key      func(d *schema.ResourceData) connectionKey
+	parseId  func(id string) (connectionKey, error)
+	formatId func(key connectionKey) string
+	// The formats of the IDs accepted on import, and how the connections and scopes given by their IDs are resolved
+	importFormat resourceIdFormat
+	resolveId    resourceIdResolver
+
+	create func(ctx context.Context, client *PipesClient, key connectionKey, req pipes.CreateConnectionRequest) (pipes.Connection, *http.Response, error)
+	get    func(ctx context.Context, client *PipesClient, key connectionKey) (pipes.Connection, *http.Response, error)
+	update func(ctx context.Context, client *PipesClient, key connectionKey, req pipes.UpdateConnectionRequest) (pipes.Connection, *http.Response, error)
+	delete func(ctx context.Context, client *PipesClient, key connectionKey) (*http.Response, error)
+}
+
+func (s connectionScope) attribute(name string) (string, bool) {
+	if renamed, ok := s.renames[name]; ok {
+		name = renamed
+	}
+	_, ok := s.attributes[name]
+	return name, ok
+}
+
+func (s connectionScope) set(d *schema.ResourceData, name string, value interface{}) {
+	if attribute, ok := s.attribute(name); ok {
+		d.Set(attribute, value)
+	}
+}
+
+// changed:: The new value of an attribute of the scope, when it has been changed and is set
+func (s connectionScope) changed(d *schema.ResourceData, name string) (interface{}, bool) {
+	attribute, ok := s.attribute(name)
+	if !ok || !d.HasChange(attribute) {
+		return nil, false
+	}
+	return d.GetOk(attribute)
+}
+
+// connectionConfig:: How the plugin and config of a connection are read from and set on the resource data,
+// so that the typed connection resources share the engine of the connection resources
+type connectionConfig struct {
+	expand  func(d *schema.ResourceData) (string, map[string]interface{})
+	flatten func(d *schema.ResourceData, config map[string]interface{}) error
+}
+
+// The config of the connection resources, as a JSON string in config or config_wo
+var connectionJSONConfig = connectionConfig{
+	expand: func(d *schema.ResourceData) (string, map[string]interface{}) {
+		var config map[string]interface{}
+
+		// Parse config OR config_wo (if provided)
+		if value, ok := d.GetOk("config"); ok {
+			_, config = formatConnectionJSONString(value.(string))
+		}
+		if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
+			_, config = formatConnectionJSONString(value.AsString())
+		}
+		return d.Get("plugin").(string), config
+	},
+	flatten: func(d *schema.ResourceData, config map[string]interface{}) error {
+		if config == nil {
+			return nil
+		}
+		configString, err := mapToJSONString(config)
+		if err != nil {
+			return err
+		}
+		// config_wo is never read back, and config is only set when it is managed by the resource
+		if _, writeConfig := d.GetOk("config"); writeConfig && configString != "" && configString != "null" {
+			d.Set("config", configString)
+		}
+		return nil
+	},
+}
+
+// connectionAttribute:: An attribute of the connection resources set from the connection returned by the API,
+// attributes whose value is not present are left as they are
+type connectionAttribute struct {
+	name  string
+	value func(conn pipes.Connection) (interface{}, bool)
+}
+
+var connectionAttributes = []connectionAttribute{
+	{"connection_id", func(conn pipes.Connection) (interface{}, bool) { return conn.Id, true }},
+	{"tenant_id", func(conn pipes.Connection) (interface{}, bool) { return conn.TenantId, true }},
+	{"identity_id", func(conn pipes.Connection) (interface{}, bool) { return conn.IdentityId, true }},
+	{"workspace_id", func(conn pipes.Connection) (interface{}, bool) { return conn.WorkspaceId, true }},
+	{"handle", func(conn pipes.Connection) (interface{}, bool) { return conn.Handle, true }},
+	{"plugin", func(conn pipes.Connection) (interface{}, bool) { return conn.Plugin, true }},
+	{"plugin_version", func(conn pipes.Connection) (interface{}, bool) { return conn.PluginVersion, true }},
+	{"type", func(conn pipes.Connection) (interface{}, bool) { return conn.Type, true }},
+	{"config_source", func(conn pipes.Connection) (interface{}, bool) { return conn.ConfigSource, true }},
+	{"credential_source", func(conn pipes.Connection) (interface{}, bool) { return conn.CredentialSource, true }},
+	{"handle_mode", func(conn pipes.Connection) (interface{}, bool) { return conn.HandleMode, true }},
+	{"handle_dynamic", func(conn pipes.Connection) (interface{}, bool) { return conn.HandleDynamic, true }},
+	{"parent_id", func(conn pipes.Connection) (interface{}, bool) { return conn.ParentId, true }},
+	{"integration_resource_name", func(conn pipes.Connection) (interface{}, bool) { return conn.IntegrationResourceName, true }},
+	{"integration_resource_identifier", func(conn pipes.Connection) (interface{}, bool) { return conn.IntegrationResourceIdentifier, true }},
+	{"integration_resource_type", func(conn pipes.Connection) (interface{}, bool) { return conn.IntegrationResourceType, true }},
+	{"integration_resource_path", func(conn pipes.Connection) (interface{}, bool) { return conn.IntegrationResourcePath, true }},
+	{"managed_by_id", func(conn pipes.Connection) (interface{}, bool) { return conn.ManagedById, true }},
+	{"trunk", func(conn pipes.Connection) (interface{}, bool) { return conn.Trunk, true }},
+	{"created_at", func(conn pipes.Connection) (interface{}, bool) { return conn.CreatedAt, true }},
+	{"updated_at", func(conn pipes.Connection) (interface{}, bool) { return conn.UpdatedAt, true }},
+	{"created_by", func(conn pipes.Connection) (interface{}, bool) {
+		if conn.CreatedBy == nil {
+			return nil, false
+		}
+		return conn.CreatedBy.Handle, true
+	}},
+	{"updated_by", func(conn pipes.Connection) (interface{}, bool) {
+		if conn.UpdatedBy == nil {
+			return nil, false
+		}
+		return conn.UpdatedBy.Handle, true
+	}},
+	{"version_id", func(conn pipes.Connection) (interface{}, bool) { return conn.VersionId, true }},
+	// computed connection state fields
+	{"status", func(conn pipes.Connection) (interface{}, bool) { return conn.Status, conn.Status != nil }},
+	{"last_error_at", func(conn pipes.Connection) (interface{}, bool) { return conn.LastErrorAt, conn.LastErrorAt != nil }},
+	{"last_error_process_id", func(conn pipes.Connection) (interface{}, bool) {
+		return conn.LastErrorProcessId, conn.LastErrorProcessId != nil
+	}},
+	{"last_successful_update_at", func(conn pipes.Connection) (interface{}, bool) {
+		return conn.LastSuccessfulUpdateAt, conn.LastSuccessfulUpdateAt != nil
+	}},
+	{"last_successful_update_process_id", func(conn pipes.Connection) (interface{}, bool) {
+		return conn.LastSuccessfulUpdateProcessId, conn.LastSuccessfulUpdateProcessId != nil
+	}},
+	{"last_update_attempt_at", func(conn pipes.Connection) (interface{}, bool) {
+		return conn.LastUpdateAttemptAt, conn.LastUpdateAttemptAt != nil
+	}},
+	{"last_update_attempt_process_id", func(conn pipes.Connection) (interface{}, bool) {
+		return conn.LastUpdateAttemptProcessId, conn.LastUpdateAttemptProcessId != nil
+	}},
+}
+
+// connectionResource:: A connection resource of a scope, whose CRUD operations are those of the connection engine
+func connectionResource(scope connectionScope) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return connectionCreate(ctx, d, meta, scope)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return connectionRead(ctx, d, meta, scope)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return connectionUpdate(ctx, d, meta, scope)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return connectionDelete(ctx, d, meta, scope)
+		},
+		CustomizeDiff: connectionConfigCustomizeDiff,
+		Importer:      resourceIdImporter(scope.importFormat, scope.resolveId),
+		Schema:        scope.attributes,
+	}
+}
+
+func connectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, scope connectionScope) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	key := scope.key(d)
+	plugin, config := scope.config.expand(d)
+
+	req := pipes.CreateConnectionRequest{
+		Handle: key.handle,
+		Plugin: plugin,
+	}
+
+	// Pass the parent_id if its set
+	if attribute, ok := scope.attribute("parent_id"); ok {
+		if value, ok := d.GetOk(attribute); ok {
+			req.SetParentId(value.(string))
+		}
+	}
+
+	// Pass the config if its set
+	if config != nil {
+		req.SetConfig(config)
+	}
+
+	client := meta.(*PipesClient)
+	resp, r, err := scope.create(ctx, client, key, req)
+	if err != nil {
+		return diag.Errorf("%sCreate. Create connection api error  %v", scope.name, decodeResponse(r))
+	}
+
+	if err = setConnectionAttributes(d, scope, key, resp); err != nil {
+		return diag.Errorf("%sCreate. Error converting config to string: %v", scope.name, err)
+	}
+
+	return diags
+}
+
+func connectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}, scope connectionScope) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	key, err := scope.parseId(d.Id())
+	if err != nil {
+		return diag.Errorf("%sRead. %v", scope.name, err)
+	}
+
+	resp, r, err := scope.get(ctx, client, key)
+	if err != nil {
+		if scope.removeNotFound && r != nil && r.StatusCode == 404 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Connection (%s) not found", key.handle),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("%sRead. Get connection error: %v", scope.name, decodeResponse(r))
+	}
+
+	if err = setConnectionAttributes(d, scope, key, resp); err != nil {
+		return diag.Errorf("%sRead. Error converting config to string: %v", scope.name, err)
+	}
+
+	return diags
+}
+
+func connectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, scope connectionScope) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	oldConnectionHandle, newConnectionHandle := d.GetChange("handle")
+	if newConnectionHandle.(string) == "" {
+		return diag.Errorf("handle must be configured")
+	}
+
+	// The connection is updated under its current handle
+	key := scope.key(d)
+	key.handle = oldConnectionHandle.(string)
+
+	_, config := scope.config.expand(d)
+
+	req := pipes.UpdateConnectionRequest{Handle: types.String(newConnectionHandle.(string))}
+	if config != nil {
+		req.SetConfig(config)
+	}
+	if value, ok := scope.changed(d, "parent_id"); ok {
+		req.SetParentId(value.(string))
+	}
+	if value, ok := scope.changed(d, "config_source"); ok {
+		req.SetConfigSource(pipes.ConnectionConfigSource(value.(string)))
+	}
+	if value, ok := scope.changed(d, "credential_source"); ok {
+		req.SetCredentialSource(pipes.ConnectionCredentialSource(value.(string)))
+	}
+
+	resp, r, err := scope.update(ctx, client, key, req)
+	if err != nil {
+		return diag.Errorf("%sUpdate. Update connection error: %v", scope.name, decodeResponse(r))
+	}
+
+	if err = setConnectionAttributes(d, scope, key, resp); err != nil {
+		return diag.Errorf("%sUpdate. Error converting config to string: %v", scope.name, err)
+	}
+
+	return diags
+}
+
+func connectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}, scope connectionScope) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	r, err := scope.delete(ctx, client, scope.key(d))
+	if err != nil {
+		return diag.Errorf("%sDelete. Delete connection error:	%v", scope.name, decodeResponse(r))
+	}
+
+	// clear the id to show we have deleted
+	d.SetId("")
+
+	return diags
+}
+
+// setConnectionAttributes:: Assign a connection returned by the API, and where it lives, back into the resource data
+func setConnectionAttributes(d *schema.ResourceData, scope connectionScope, key connectionKey, conn pipes.Connection) error {
+	if err := scope.config.flatten(d, conn.GetConfig()); err != nil {
+		return err
+	}
+	for _, attribute := range connectionAttributes {
+		if value, ok := attribute.value(conn); ok {
+			scope.set(d, attribute.name, value)
+		}
+	}
+	scope.set(d, "organization", key.organization)
+	scope.set(d, "workspace", key.workspace)
+
+	key.tenant = conn.TenantId
+	key.handle = conn.GetHandle()
+	d.SetId(scope.formatId(key))
+	return nil
+}
+
+// resolveConnectionHandle:: Replace the connection of an imported ID given by its ID by its handle, read with the get
+// adapter of the scope
+func resolveConnectionHandle(ctx context.Context, client *PipesClient, id resourceId, get func(ctx context.Context, client *PipesClient, key connectionKey) (pipes.Connection, *http.Response, error), key connectionKey) error {
+	if !isPipesId(key.handle, "c") {
+		return nil
+	}
+	conn, r, err := get(ctx, client, key)
+	if err = resolveError(key.handle, r, err); err != nil {
+		return err
+	}
+	if conn.GetHandle() != "" {
+		id["connection-handle"] = conn.GetHandle()
+	}
+	return nil
+}
+
+// importedConnectionId:: The ID of a connection imported by its ID alone, i.e. without its tenant, organization or workspace
+func importedConnectionId(id resourceId) (string, bool, error) {
+	connectionId, ok := id["connection-id"]
+	if !ok {
+		return "", false, nil
+	}
+	if !isPipesId(connectionId, "c") {
+		return "", false, fmt.Errorf("invalid ID %q, a connection imported without its scope must be given by its ID, e.g. c_cdi2d6c3ul7ugl1l6e30", connectionId)
+	}
+	delete(id, "connection-id")
+	return connectionId, true, nil
+}
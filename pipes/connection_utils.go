@@ -0,0 +1,195 @@
+package pipes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// connectionConfigArgument:: A connection config argument of a plugin, secrets are write-only in the typed connection resources
+type connectionConfigArgument struct {
+	Type   string
+	Secret bool
+}
+
+// The connection config arguments of the plugins known to the provider.
+// The Turbot Pipes API does not expose the connection config schema of the plugins, so the
+// arguments are those documented for each plugin on the Steampipe Hub.
+var connectionConfigSchemas = map[string]map[string]connectionConfigArgument{
+	"aws": {
+		"access_key":               {Type: "string"},
+		"default_region":           {Type: "string"},
+		"endpoint_url":             {Type: "string"},
+		"ignore_error_codes":       {Type: "list(string)"},
+		"ignore_error_messages":    {Type: "list(string)"},
+		"max_error_retry_attempts": {Type: "number"},
+		"min_error_retry_delay":    {Type: "number"},
+		"profile":                  {Type: "string"},
+		"regions":                  {Type: "list(string)"},
+		"s3_force_path_style":      {Type: "bool"},
+		"secret_key":               {Type: "string", Secret: true},
+		"session_token":            {Type: "string", Secret: true},
+	},
+	"azure": {
+		"certificate_password": {Type: "string", Secret: true},
+		"certificate_path":     {Type: "string"},
+		"client_id":            {Type: "string"},
+		"client_secret":        {Type: "string", Secret: true},
+		"environment":          {Type: "string"},
+		"ignore_error_codes":   {Type: "list(string)"},
+		"password":             {Type: "string", Secret: true},
+		"subscription_id":      {Type: "string"},
+		"tenant_id":            {Type: "string"},
+		"username":             {Type: "string"},
+	},
+	"gcp": {
+		"credentials":                 {Type: "string", Secret: true},
+		"ignore_error_codes":          {Type: "list(string)"},
+		"impersonate_service_account": {Type: "string"},
+		"project":                     {Type: "string"},
+	},
+	"github": {
+		"base_url": {Type: "string"},
+		"token":    {Type: "string", Secret: true},
+	},
+	"kubernetes": {
+		"config_context":         {Type: "string"},
+		"config_path":            {Type: "string"},
+		"config_paths":           {Type: "list(string)"},
+		"custom_resource_tables": {Type: "list(string)"},
+		"manifest_file_paths":    {Type: "list(string)"},
+		"source_types":           {Type: "list(string)"},
+	},
+}
+
+// connectionConfigCustomizeDiff:: Validate at plan time the config (or config_wo) of a connection against the
+// config schema of its plugin. Values of the wrong type are rejected, and so are unknown arguments which
+// are a likely misspelling of a known one, i.e. within an edit distance of 2 ignoring case. Other unknown
+// arguments are left for the API to validate, since the schema of the plugin may be newer than the one
+// known to the provider.
+func connectionConfigCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("plugin") {
+		return nil
+	}
+	plugin := connectionPluginName(d.Get("plugin").(string))
+
+	if d.NewValueKnown("config") {
+		if err := validateConnectionConfig(plugin, "config", d.Get("config").(string)); err != nil {
+			return err
+		}
+	}
+	// config_wo is write-only, so it can only be read from the configuration
+	if value := d.GetRawConfig().GetAttr("config_wo"); value.IsKnown() && !value.IsNull() {
+		if err := validateConnectionConfig(plugin, "config_wo", value.AsString()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connectionPluginName:: The name of a plugin from its reference, e.g. "aws" for "turbot/aws@latest"
+func connectionPluginName(plugin string) string {
+	plugin = strings.SplitN(plugin, "@", 2)[0]
+	return plugin[strings.LastIndex(plugin, "/")+1:]
+}
+
+// validateConnectionConfig:: Validate a connection config against the config schema of its plugin. The config of a plugin
+// with no known schema is left as-is for the API to validate.
+func validateConnectionConfig(plugin, key, body string) error {
+	arguments, ok := connectionConfigSchemas[plugin]
+	if !ok || body == "" {
+		return nil
+	}
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &config); err != nil {
+		return fmt.Errorf("%s: the connection config must be a JSON object: %v", key, err)
+	}
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	for _, name := range names {
+		value := config[name]
+		argument, ok := arguments[name]
+		if !ok {
+			if suggestion := suggestConnectionConfigArgument(name, arguments); suggestion != "" {
+				errs = append(errs, fmt.Sprintf("unsupported argument %q for plugin %s, did you mean %q?", name, plugin, suggestion))
+			}
+			continue
+		}
+		if value != nil && !isConnectionConfigValueOfType(value, argument.Type) {
+			errs = append(errs, fmt.Sprintf("argument %q for plugin %s must be of type %s", name, plugin, argument.Type))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: invalid connection config:\n  - %s", key, strings.Join(errs, "\n  - "))
+	}
+	return nil
+}
+
+func isConnectionConfigValueOfType(value interface{}, argumentType string) bool {
+	switch argumentType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "bool":
+		_, ok := value.(bool)
+		return ok
+	case "list(string)":
+		items, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// suggestConnectionConfigArgument:: The known argument closest to name, when it is close enough to be a misspelling
+func suggestConnectionConfigArgument(name string, arguments map[string]connectionConfigArgument) string {
+	suggestion := ""
+	best := 3
+	for argument := range arguments {
+		distance := levenshteinDistance(strings.ToLower(name), argument)
+		if distance < best || distance == best && suggestion != "" && argument < suggestion {
+			suggestion, best = argument, distance
+		}
+	}
+	return suggestion
+}
+
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,114 @@
+package pipes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConnectionConfig(t *testing.T) {
+	cases := map[string]struct {
+		plugin   string
+		config   string
+		expected []string
+	}{
+		"empty":                   {plugin: "aws", config: ""},
+		"valid":                   {plugin: "aws", config: `{"regions": ["us-east-1"], "max_error_retry_attempts": 5, "s3_force_path_style": true, "profile": null}`},
+		"unknown argument":        {plugin: "aws", config: `{"regions": ["us-east-1"], "assume_role_arn": "arn:aws:iam::123456789012:role/steampipe"}`},
+		"misspelled argument":     {plugin: "aws", config: `{"region": ["us-east-1"]}`, expected: []string{`unsupported argument "region" for plugin aws, did you mean "regions"?`}},
+		"two edits away":          {plugin: "github", config: `{"base_uri": "https://github.example.com/api/v3", "tokn": "ghp_xxx"}`, expected: []string{`unsupported argument "base_uri" for plugin github, did you mean "base_url"?`, `unsupported argument "tokn" for plugin github, did you mean "token"?`}},
+		"three edits away":        {plugin: "github", config: `{"app_id": "123"}`},
+		"misspelled in uppercase": {plugin: "github", config: `{"TOKEN": "ghp_xxx"}`, expected: []string{`unsupported argument "TOKEN" for plugin github, did you mean "token"?`}},
+		"wrong type": {plugin: "aws", config: `{"regions": "us-east-1", "max_error_retry_attempts": "5", "s3_force_path_style": 1, "ignore_error_codes": ["AccessDenied", 403]}`, expected: []string{
+			`argument "ignore_error_codes" for plugin aws must be of type list(string)`,
+			`argument "max_error_retry_attempts" for plugin aws must be of type number`,
+			`argument "regions" for plugin aws must be of type list(string)`,
+			`argument "s3_force_path_style" for plugin aws must be of type bool`,
+		}},
+		"several errors in order": {plugin: "gcp", config: `{"projct": "my-project", "credentials": 1}`, expected: []string{
+			`argument "credentials" for plugin gcp must be of type string`,
+			`unsupported argument "projct" for plugin gcp, did you mean "project"?`,
+		}},
+		"not an object":             {plugin: "aws", config: `["us-east-1"]`, expected: []string{"config: the connection config must be a JSON object"}},
+		"not JSON":                  {plugin: "aws", config: `regions = ["us-east-1"]`, expected: []string{"config: the connection config must be a JSON object"}},
+		"unknown plugin":            {plugin: "net", config: `{"timeout": "wrong"}`},
+		"unknown plugin not object": {plugin: "net", config: `["not", "an", "object"]`},
+	}
+	for name, c := range cases {
+		err := validateConnectionConfig(c.plugin, "config", c.config)
+		if len(c.expected) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+			continue
+		}
+		if !strings.HasPrefix(err.Error(), "config: ") {
+			t.Errorf("%s: expected the error to name the attribute, got %v", name, err)
+		}
+		// the errors are listed one per line, ordered by argument name
+		last := -1
+		for _, expected := range c.expected {
+			index := strings.Index(err.Error(), expected)
+			if index < 0 {
+				t.Errorf("%s: expected the error to contain %q, got %v", name, expected, err)
+			} else if index < last {
+				t.Errorf("%s: expected %q to be listed in argument order, got %v", name, expected, err)
+			}
+			last = index
+		}
+	}
+}
+
+func TestSuggestConnectionConfigArgument(t *testing.T) {
+	cases := map[string]struct {
+		name     string
+		expected string
+	}{
+		"missing letter":     {name: "region", expected: "regions"},
+		"extra letter":       {name: "access_keyy", expected: "access_key"},
+		"swapped letters":    {name: "porfile", expected: "profile"},
+		"uppercase":          {name: "Default_Region", expected: "default_region"},
+		"too far":            {name: "assume_role_arn", expected: ""},
+		"unrelated":          {name: "x", expected: ""},
+		"exact match":        {name: "profile", expected: "profile"},
+		"separator mismatch": {name: "secret-key", expected: "secret_key"},
+	}
+	for name, c := range cases {
+		if got := suggestConnectionConfigArgument(c.name, connectionConfigSchemas["aws"]); got != c.expected {
+			t.Errorf("%s: expected %q for %q, got %q", name, c.expected, c.name, got)
+		}
+	}
+
+	// ties are broken by name, so that the suggestion is stable
+	arguments := map[string]connectionConfigArgument{"bbc": {Type: "string"}, "abd": {Type: "string"}}
+	if got := suggestConnectionConfigArgument("abc", arguments); got != "abd" {
+		t.Errorf("expected the first of the closest arguments by name, got %q", got)
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"regions", "regions", 0},
+		{"region", "regions", 1},
+		{"regoins", "regions", 2},
+		{"kitten", "sitting", 3},
+		{"token", "base_url", 7},
+	}
+	for _, c := range cases {
+		if got := levenshteinDistance(c.a, c.b); got != c.expected {
+			t.Errorf("levenshteinDistance(%q, %q): expected %d, got %d", c.a, c.b, c.expected, got)
+		}
+		if got := levenshteinDistance(c.b, c.a); got != c.expected {
+			t.Errorf("levenshteinDistance(%q, %q): expected %d, got %d", c.b, c.a, c.expected, got)
+		}
+	}
+}
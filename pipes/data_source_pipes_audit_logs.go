@@ -0,0 +1,325 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceAuditLogs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAuditLogsRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"action_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"actor_handles": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"target_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"target_handle": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"start_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      auditLogsDefaultMaxResults,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"audit_logs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"audit_log_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"actor_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"identity_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"identity_handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"target_handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"process_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"data": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// auditLogsPageSize is the largest page of audit records the API returns, the list APIs do not support any filter
+// other than the page size so the filters are applied to the pages of records
+const auditLogsPageSize = 100
+
+// auditLogsDefaultMaxResults bounds the number of audit records read when max_results is not set, so that a refresh
+// does not page through the whole audit history of the scope
+const auditLogsDefaultMaxResults = 1000
+
+// auditLogFilter holds the client side filters applied to the audit records returned by the API
+type auditLogFilter struct {
+	actionTypes  map[string]bool
+	actorHandles map[string]bool
+	targetId     string
+	targetHandle string
+	startTime    *time.Time
+	endTime      *time.Time
+}
+
+func (f *auditLogFilter) matches(record pipes.AuditRecord) bool {
+	if len(f.actionTypes) > 0 && !f.actionTypes[record.ActionType] {
+		return false
+	}
+	if len(f.actorHandles) > 0 && !f.actorHandles[record.ActorHandle] {
+		return false
+	}
+	if f.targetId != "" && record.GetTargetId() != f.targetId {
+		return false
+	}
+	if f.targetHandle != "" && record.GetTargetHandle() != f.targetHandle {
+		return false
+	}
+	if f.startTime != nil || f.endTime != nil {
+		createdAt, err := time.Parse(time.RFC3339, record.CreatedAt)
+		if err != nil {
+			return false
+		}
+		if f.startTime != nil && createdAt.Before(*f.startTime) {
+			return false
+		}
+		if f.endTime != nil && createdAt.After(*f.endTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// exhausted returns true if the record is older than the start time. The audit records are returned newest first,
+// so none of the records after it can match.
+func (f *auditLogFilter) exhausted(record pipes.AuditRecord) bool {
+	if f.startTime == nil {
+		return false
+	}
+	createdAt, err := time.Parse(time.RFC3339, record.CreatedAt)
+	return err == nil && createdAt.Before(*f.startTime)
+}
+
+func dataSourceAuditLogsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.ListAuditLogsResponse
+	var r *http.Response
+	var err error
+	var actorHandle, nextToken, tfId string
+
+	client := meta.(*PipesClient)
+
+	orgHandle := d.Get("organization").(string)
+	workspaceHandle := d.Get("workspace").(string)
+	maxResults := d.Get("max_results").(int)
+
+	// Build the filters
+	filter := auditLogFilter{
+		actionTypes:  map[string]bool{},
+		actorHandles: map[string]bool{},
+		targetId:     d.Get("target_id").(string),
+		targetHandle: d.Get("target_handle").(string),
+	}
+	actionTypes, err := convertToStringArray(d.Get("action_types").([]interface{}))
+	if err != nil {
+		return diag.Errorf("dataSourceAuditLogsRead.action_types error  %v", err.Error())
+	}
+	for _, actionType := range actionTypes {
+		filter.actionTypes[actionType] = true
+	}
+	actorHandles, err := convertToStringArray(d.Get("actor_handles").([]interface{}))
+	if err != nil {
+		return diag.Errorf("dataSourceAuditLogsRead.actor_handles error  %v", err.Error())
+	}
+	for _, handle := range actorHandles {
+		filter.actorHandles[handle] = true
+	}
+	if val, ok := d.GetOk("start_time"); ok {
+		startTime, _ := time.Parse(time.RFC3339, val.(string))
+		filter.startTime = &startTime
+	}
+	if val, ok := d.GetOk("end_time"); ok {
+		endTime, _ := time.Parse(time.RFC3339, val.(string))
+		filter.endTime = &endTime
+	}
+
+	// The scope of the audit logs is the tenant if neither an organization nor a workspace is passed,
+	// a workspace if one is passed, otherwise the organization
+	switch {
+	case workspaceHandle != "" && orgHandle == "":
+		actorHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceAuditLogsRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		tfId = workspaceHandle
+	case workspaceHandle != "":
+		tfId = fmt.Sprintf("%s/%s", orgHandle, workspaceHandle)
+	case orgHandle != "":
+		tfId = orgHandle
+	default:
+		tfId = "tenant"
+	}
+
+	var items []map[string]interface{}
+	var done bool
+	for !done {
+		switch {
+		case workspaceHandle != "" && orgHandle == "":
+			req := client.APIClient.UserWorkspaces.ListAuditLogs(ctx, actorHandle, workspaceHandle).Limit(auditLogsPageSize)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		case workspaceHandle != "":
+			req := client.APIClient.OrgWorkspaces.ListAuditLogs(ctx, orgHandle, workspaceHandle).Limit(auditLogsPageSize)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		case orgHandle != "":
+			req := client.APIClient.Orgs.ListAuditLogs(ctx, orgHandle).Limit(auditLogsPageSize)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		default:
+			req := client.APIClient.Tenants.ListAuditLogs(ctx).Limit(auditLogsPageSize)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error listing audit logs: %v", decodeResponse(r))
+		}
+
+		if resp.Items != nil {
+			for _, record := range *resp.Items {
+				if filter.exhausted(record) {
+					done = true
+					break
+				}
+				if !filter.matches(record) {
+					continue
+				}
+				items = append(items, map[string]interface{}{
+					"audit_log_id":       record.Id,
+					"action_type":        record.ActionType,
+					"actor_id":           record.ActorId,
+					"actor_handle":       record.ActorHandle,
+					"actor_display_name": record.ActorDisplayName,
+					"actor_ip":           record.ActorIp,
+					"identity_id":        record.GetIdentityId(),
+					"identity_handle":    record.GetIdentityHandle(),
+					"target_id":          record.GetTargetId(),
+					"target_handle":      record.GetTargetHandle(),
+					"process_id":         record.GetProcessId(),
+					"tenant_id":          record.TenantId,
+					"data":               FormatJson(record.Data),
+					"created_at":         record.CreatedAt,
+				})
+				if len(items) >= maxResults {
+					done = true
+					break
+				}
+			}
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			done = true
+		} else {
+			nextToken = *resp.NextToken
+		}
+	}
+	log.Printf("\n[DEBUG] %d audit logs matched for scope: %s", len(items), tfId)
+
+	if err := d.Set("audit_logs", items); err != nil {
+		return diag.Errorf("error setting audit_logs: %v", err)
+	}
+	d.SetId(tfId)
+
+	return diags
+}
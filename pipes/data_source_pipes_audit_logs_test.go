@@ -0,0 +1,109 @@
+package pipes
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func TestAccUserWorkspaceAuditLogsDataSource_Basic(t *testing.T) {
+	dataSourceName := "data.pipes_audit_logs.test"
+	workspaceHandle := "workspace" + randomString(3)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspaceAuditLogsDataSourceConfig(workspaceHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "workspace", workspaceHandle),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.action_type", "workspace.create"),
+					resource.TestCheckResourceAttr(dataSourceName, "audit_logs.0.target_handle", workspaceHandle),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserWorkspaceAuditLogsDataSourceConfig(workspaceHandle string) string {
+	return fmt.Sprintf(`
+resource "pipes_workspace" "test_workspace" {
+	handle = "%s"
+}
+
+data "pipes_audit_logs" "test" {
+	workspace    = pipes_workspace.test_workspace.handle
+	action_types = ["workspace.create"]
+	max_results  = 1
+}`, workspaceHandle)
+}
+
+func TestAuditLogFilterExhausted(t *testing.T) {
+	startTime, _ := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
+	cases := map[string]struct {
+		filter    auditLogFilter
+		createdAt string
+		expected  bool
+	}{
+		"no start time":     {auditLogFilter{}, "2024-01-01T00:00:00Z", false},
+		"after start time":  {auditLogFilter{startTime: &startTime}, "2025-06-01T00:00:00Z", false},
+		"at start time":     {auditLogFilter{startTime: &startTime}, "2025-01-01T00:00:00Z", false},
+		"before start time": {auditLogFilter{startTime: &startTime}, "2024-12-31T23:59:59Z", true},
+		"invalid time":      {auditLogFilter{startTime: &startTime}, "yesterday", false},
+	}
+	for name, c := range cases {
+		if got := c.filter.exhausted(pipes.AuditRecord{CreatedAt: c.createdAt}); got != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, got)
+		}
+	}
+}
+
+func TestAuditLogFilterMatches(t *testing.T) {
+	startTime, _ := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
+	endTime, _ := time.Parse(time.RFC3339, "2025-02-01T00:00:00Z")
+	record := pipes.AuditRecord{
+		ActionType:   "workspace.create",
+		ActorHandle:  "jane",
+		TargetId:     pipes.PtrString("w_cdi2d6c3ul7ugl1l6e30"),
+		TargetHandle: pipes.PtrString("dev"),
+		CreatedAt:    "2025-01-15T12:00:00Z",
+	}
+	cases := map[string]struct {
+		filter   auditLogFilter
+		record   pipes.AuditRecord
+		expected bool
+	}{
+		"no filter":               {auditLogFilter{}, record, true},
+		"action type":             {auditLogFilter{actionTypes: map[string]bool{"workspace.create": true, "workspace.delete": true}}, record, true},
+		"other action type":       {auditLogFilter{actionTypes: map[string]bool{"workspace.delete": true}}, record, false},
+		"actor handle":            {auditLogFilter{actorHandles: map[string]bool{"jane": true}}, record, true},
+		"other actor handle":      {auditLogFilter{actorHandles: map[string]bool{"john": true}}, record, false},
+		"target ID":               {auditLogFilter{targetId: "w_cdi2d6c3ul7ugl1l6e30"}, record, true},
+		"other target ID":         {auditLogFilter{targetId: "w_aaaaaaaaaaaaaaaaaaaa"}, record, false},
+		"target handle":           {auditLogFilter{targetHandle: "dev"}, record, true},
+		"other target handle":     {auditLogFilter{targetHandle: "prod"}, record, false},
+		"no target":               {auditLogFilter{targetHandle: "dev"}, pipes.AuditRecord{CreatedAt: record.CreatedAt}, false},
+		"within time range":       {auditLogFilter{startTime: &startTime, endTime: &endTime}, record, true},
+		"before start time":       {auditLogFilter{startTime: &endTime}, record, false},
+		"after end time":          {auditLogFilter{endTime: &startTime}, record, false},
+		"invalid time with range": {auditLogFilter{startTime: &startTime}, pipes.AuditRecord{CreatedAt: "yesterday"}, false},
+		"all filters": {auditLogFilter{
+			actionTypes:  map[string]bool{"workspace.create": true},
+			actorHandles: map[string]bool{"jane": true},
+			targetId:     "w_cdi2d6c3ul7ugl1l6e30",
+			targetHandle: "dev",
+			startTime:    &startTime,
+			endTime:      &endTime,
+		}, record, true},
+	}
+	for name, c := range cases {
+		if got := c.filter.matches(c.record); got != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, got)
+		}
+	}
+}
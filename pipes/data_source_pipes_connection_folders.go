@@ -0,0 +1,346 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceConnectionFolders() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectionFoldersRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"workspace": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"folders": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_folder_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"trunk_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"child_folder_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"connection_handles": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"connections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"plugin": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"folder_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"folder_ids_by_path": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceConnectionFoldersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.ListConnectionsResponse
+	var r *http.Response
+	var err error
+	var actorHandle, nextToken, tfId string
+	var items []pipes.Connection
+
+	client := meta.(*PipesClient)
+
+	orgHandle := d.Get("organization").(string)
+	workspaceHandle := d.Get("workspace").(string)
+
+	// The scope of the tree is the tenant if neither an organization nor a workspace is passed,
+	// a workspace if one is passed, otherwise the organization
+	switch {
+	case workspaceHandle != "" && orgHandle == "":
+		actorHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceConnectionFoldersRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		tfId = workspaceHandle
+	case workspaceHandle != "":
+		tfId = fmt.Sprintf("%s/%s", orgHandle, workspaceHandle)
+	case orgHandle != "":
+		tfId = orgHandle
+	default:
+		tfId = "tenant"
+	}
+
+	// Page through the connection tree of the scope
+	for {
+		switch {
+		case workspaceHandle != "" && orgHandle == "":
+			req := client.APIClient.UserWorkspaceConnectionTree.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		case workspaceHandle != "":
+			req := client.APIClient.OrgWorkspaceConnectionTree.List(ctx, orgHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		case orgHandle != "":
+			req := client.APIClient.OrgConnectionTree.List(ctx, orgHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		default:
+			req := client.APIClient.TenantConnectionTree.List(ctx)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error listing connection tree: %v", decodeResponse(r))
+		}
+		if resp.Items != nil {
+			items = append(items, *resp.Items...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+	log.Printf("\n[DEBUG] %d connection tree items received for scope: %s", len(items), tfId)
+
+	tree := buildConnectionFolderTree(items)
+
+	folders := make([]map[string]interface{}, 0, len(tree.folders))
+	for _, folder := range tree.folders {
+		folders = append(folders, map[string]interface{}{
+			"connection_folder_id": folder.id,
+			"title":                folder.title,
+			"parent_id":            folder.parentId,
+			"path":                 folder.path,
+			"trunk_ids":            folder.trunkIds,
+			"child_folder_ids":     folder.childFolderIds,
+			"connection_handles":   folder.connectionHandles,
+		})
+	}
+	connections := make([]map[string]interface{}, 0, len(tree.connections))
+	for _, connection := range tree.connections {
+		connections = append(connections, map[string]interface{}{
+			"connection_id": connection.Id,
+			"handle":        connection.GetHandle(),
+			"type":          connection.GetType(),
+			"plugin":        connection.GetPlugin(),
+			"parent_id":     connection.ParentId,
+			"folder_path":   tree.folderPath(connection.ParentId),
+		})
+	}
+
+	if err := d.Set("folders", folders); err != nil {
+		return diag.Errorf("error setting folders: %v", err)
+	}
+	if err := d.Set("connections", connections); err != nil {
+		return diag.Errorf("error setting connections: %v", err)
+	}
+	folderIdsByPath, duplicates := tree.folderIdsByPath()
+	if err := d.Set("folder_ids_by_path", folderIdsByPath); err != nil {
+		return diag.Errorf("error setting folder_ids_by_path: %v", err)
+	}
+	for _, path := range duplicates {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Connection folder path %q is shared by several folders", path),
+			Detail:   "Sibling folders with the same title have the same path, so the path is left out of folder_ids_by_path. Reference these folders by ID, or rename them so that their paths are unique.",
+		})
+	}
+	d.SetId(tfId)
+
+	return diags
+}
+
+type connectionFolderNode struct {
+	id                string
+	title             string
+	parentId          string
+	path              string
+	trunkIds          []string
+	childFolderIds    []string
+	connectionHandles []string
+}
+
+type connectionFolderTree struct {
+	folders     []*connectionFolderNode
+	byId        map[string]*connectionFolderNode
+	connections []pipes.Connection
+}
+
+// isConnectionFolder:: Check if an item of the connection tree is a connection folder rather than a connection or aggregator
+func isConnectionFolder(item pipes.Connection) bool {
+	return item.GetType() == "connection-folder" || strings.HasPrefix(item.Id, "f_")
+}
+
+// buildConnectionFolderTree links the flat list of items returned by the connection tree API into
+// folders, computing for each folder the slash separated path of folder titles from the root of the scope
+func buildConnectionFolderTree(items []pipes.Connection) *connectionFolderTree {
+	tree := &connectionFolderTree{byId: map[string]*connectionFolderNode{}}
+
+	for _, item := range items {
+		if isConnectionFolder(item) {
+			node := &connectionFolderNode{
+				id:                item.Id,
+				title:             item.GetTitle(),
+				parentId:          item.ParentId,
+				childFolderIds:    []string{},
+				connectionHandles: []string{},
+			}
+			tree.folders = append(tree.folders, node)
+			tree.byId[item.Id] = node
+		} else {
+			tree.connections = append(tree.connections, item)
+		}
+	}
+
+	for _, folder := range tree.folders {
+		if parent, ok := tree.byId[folder.parentId]; ok {
+			parent.childFolderIds = append(parent.childFolderIds, folder.id)
+		}
+	}
+	for _, connection := range tree.connections {
+		if parent, ok := tree.byId[connection.ParentId]; ok {
+			parent.connectionHandles = append(parent.connectionHandles, connection.GetHandle())
+		}
+	}
+	for _, folder := range tree.folders {
+		var titles []string
+		folder.trunkIds = []string{}
+		// Walk up to the root, guarding against a malformed tree containing a cycle
+		for current, depth := folder, 0; current != nil && depth <= len(tree.folders); depth++ {
+			titles = append([]string{current.title}, titles...)
+			if current != folder {
+				folder.trunkIds = append([]string{current.id}, folder.trunkIds...)
+			}
+			current = tree.byId[current.parentId]
+		}
+		folder.path = strings.Join(titles, "/")
+		sort.Strings(folder.childFolderIds)
+		sort.Strings(folder.connectionHandles)
+	}
+
+	sort.Slice(tree.folders, func(i, j int) bool { return tree.folders[i].path < tree.folders[j].path })
+	sort.Slice(tree.connections, func(i, j int) bool {
+		return tree.connections[i].GetHandle() < tree.connections[j].GetHandle()
+	})
+	return tree
+}
+
+// folderPath returns the path of the folder with the given id, or an empty string if the item is
+// stored at the root of the scope
+func (t *connectionFolderTree) folderPath(id string) string {
+	if folder, ok := t.byId[id]; ok {
+		return folder.path
+	}
+	return ""
+}
+
+// isInFolder checks if an item with the given parent is stored in the folder with the given id or any of its subfolders
+func (t *connectionFolderTree) isInFolder(parentId, folderId string) bool {
+	// Walk up to the root, guarding against a malformed tree containing a cycle
+	for depth := 0; parentId != "" && depth <= len(t.folders); depth++ {
+		if parentId == folderId {
+			return true
+		}
+		folder, ok := t.byId[parentId]
+		if !ok {
+			return false
+		}
+		parentId = folder.parentId
+	}
+	return false
+}
+
+// folderIdsByPath returns the ids of the folders by path. Sibling folders may share a title, so the paths shared by
+// several folders are left out, as they cannot reference a single folder, and returned sorted instead.
+func (t *connectionFolderTree) folderIdsByPath() (map[string]string, []string) {
+	result := map[string]string{}
+	shared := map[string]bool{}
+	for _, folder := range t.folders {
+		if _, ok := result[folder.path]; ok {
+			shared[folder.path] = true
+		}
+		result[folder.path] = folder.id
+	}
+	duplicates := []string{}
+	for path := range shared {
+		delete(result, path)
+		duplicates = append(duplicates, path)
+	}
+	sort.Strings(duplicates)
+	return result, duplicates
+}
@@ -0,0 +1,84 @@
+package pipes
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func TestAccOrgConnectionFoldersDataSource_Basic(t *testing.T) {
+	dataSourceName := "data.pipes_connection_folders.test"
+	orgHandle := "terraform" + randomString(9)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgConnectionFoldersDataSourceConfig(orgHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "folders.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "folders.0.path", "aws"),
+					resource.TestCheckResourceAttr(dataSourceName, "folders.1.path", "aws/prod"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "folder_ids_by_path.aws/prod", "pipes_organization_connection_folder.prod", "connection_folder_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "folders.0.trunk_ids.#", "0"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "folders.1.trunk_ids.0", dataSourceName, "folders.0.connection_folder_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "folders.1.connection_handles.0", "aws_prod"),
+					resource.TestCheckResourceAttr(dataSourceName, "connections.0.folder_path", "aws/prod"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrgConnectionFoldersDataSourceConfig(orgHandle string) string {
+	return fmt.Sprintf(`
+resource "pipes_organization" "test" {
+	handle       = "%s"
+	display_name = "Terraform Test Org"
+}
+
+resource "pipes_organization_connection_folder" "aws" {
+	organization = pipes_organization.test.handle
+	title        = "aws"
+}
+
+resource "pipes_organization_connection_folder" "prod" {
+	organization = pipes_organization.test.handle
+	title        = "prod"
+	parent_id    = pipes_organization_connection_folder.aws.connection_folder_id
+}
+
+resource "pipes_organization_connection" "aws_prod" {
+	organization = pipes_organization.test.handle
+	handle       = "aws_prod"
+	plugin       = "aws"
+	parent_id    = pipes_organization_connection_folder.prod.connection_folder_id
+}
+
+data "pipes_connection_folders" "test" {
+	organization = pipes_organization_connection.aws_prod.organization
+}`, orgHandle)
+}
+
+func TestConnectionFolderTreeFolderIdsByPath(t *testing.T) {
+	tree := buildConnectionFolderTree([]pipes.Connection{
+		{Id: "f_aaaaaaaaaaaaaaaaaaaa", Type: pipes.PtrString("connection-folder"), Title: pipes.PtrString("aws")},
+		{Id: "f_bbbbbbbbbbbbbbbbbbbb", Type: pipes.PtrString("connection-folder"), Title: pipes.PtrString("prod"), ParentId: "f_aaaaaaaaaaaaaaaaaaaa"},
+		{Id: "f_cccccccccccccccccccc", Type: pipes.PtrString("connection-folder"), Title: pipes.PtrString("prod"), ParentId: "f_aaaaaaaaaaaaaaaaaaaa"},
+		{Id: "f_dddddddddddddddddddd", Type: pipes.PtrString("connection-folder"), Title: pipes.PtrString("us"), ParentId: "f_bbbbbbbbbbbbbbbbbbbb"},
+	})
+
+	// Sibling folders with the same title share a path, which is left out rather than referencing either of them
+	ids, duplicates := tree.folderIdsByPath()
+	expected := map[string]string{"aws": "f_aaaaaaaaaaaaaaaaaaaa", "aws/prod/us": "f_dddddddddddddddddddd"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+	if !reflect.DeepEqual(duplicates, []string{"aws/prod"}) {
+		t.Errorf("expected the duplicate path aws/prod, got %v", duplicates)
+	}
+}
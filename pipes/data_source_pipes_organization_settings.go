@@ -0,0 +1,82 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOrganizationSettings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceOrganizationSettingsRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"token_min_issued_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"usage_compute_action": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"usage_compute_threshold": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"usage_storage_action": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"usage_storage_threshold": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"usage_user_action": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"usage_user_threshold": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceOrganizationSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	orgHandle := d.Get("organization").(string)
+	resp, r, err := client.APIClient.Orgs.Get(ctx, orgHandle).Execute()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("%v", decodeResponse(r)))
+	}
+
+	resourceOrganizationSettingsPopulateFromResponse(d, resp)
+
+	return diags
+}
@@ -0,0 +1,137 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceWorkspaceDatatank() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWorkspaceDatatankRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"workspace_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datatank_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"identity_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"workspace_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"desired_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceDatatankRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.Datatank
+	var r *http.Response
+	var err error
+
+	workspaceHandle := d.Get("workspace_handle").(string)
+	datatankHandle := d.Get("handle").(string)
+
+	client := meta.(*PipesClient)
+
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		var actorHandle string
+		actorHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceWorkspaceDatatankRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		resp, r, err = client.APIClient.UserWorkspaceDatatanks.Get(ctx, actorHandle, workspaceHandle, datatankHandle).Execute()
+	} else {
+		resp, r, err = client.APIClient.OrgWorkspaceDatatanks.Get(ctx, orgHandle, workspaceHandle, datatankHandle).Execute()
+	}
+	if err != nil {
+		return diag.Errorf("error reading workspace datatank: %v", decodeResponse(r))
+	}
+	log.Printf("\n[DEBUG] Datatank: %s received for Workspace: %s", resp.Handle, workspaceHandle)
+
+	d.Set("datatank_id", resp.Id)
+	d.Set("organization", orgHandle)
+	d.Set("identity_id", resp.IdentityId)
+	d.Set("workspace_handle", workspaceHandle)
+	d.Set("workspace_id", resp.WorkspaceId)
+	d.Set("handle", resp.Handle)
+	d.Set("description", resp.Description)
+	d.Set("state", resp.State)
+	d.Set("state_reason", resp.StateReason)
+	d.Set("desired_state", resp.DesiredState)
+	d.Set("created_at", resp.CreatedAt)
+	d.Set("updated_at", resp.UpdatedAt)
+	if resp.CreatedBy != nil {
+		d.Set("created_by", resp.CreatedBy.Handle)
+	}
+	if resp.UpdatedBy != nil {
+		d.Set("updated_by", resp.UpdatedBy.Handle)
+	}
+	d.Set("version_id", resp.VersionId)
+
+	if isUser {
+		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Handle))
+	} else {
+		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Handle))
+	}
+
+	return diags
+}
@@ -0,0 +1,183 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceWorkspaceDatatankTable() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWorkspaceDatatankTableRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"workspace_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datatank_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datatank_table_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"datatank_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"migrating_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"part_per": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_schema": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_table": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_query": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"desired_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"freshness": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"migrating_freshness": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"frequency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceDatatankTableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.DatatankTable
+	var r *http.Response
+	var err error
+
+	workspaceHandle := d.Get("workspace_handle").(string)
+	datatankHandle := d.Get("datatank_handle").(string)
+	tableName := d.Get("name").(string)
+
+	client := meta.(*PipesClient)
+
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		var actorHandle string
+		actorHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceWorkspaceDatatankTableRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		resp, r, err = client.APIClient.UserWorkspaceDatatankTables.Get(ctx, actorHandle, workspaceHandle, datatankHandle, tableName).Execute()
+	} else {
+		resp, r, err = client.APIClient.OrgWorkspaceDatatankTables.Get(ctx, orgHandle, workspaceHandle, datatankHandle, tableName).Execute()
+	}
+	if err != nil {
+		return diag.Errorf("error reading workspace datatank table: %v", decodeResponse(r))
+	}
+	log.Printf("\n[DEBUG] Datatank table: %s received for Datatank: %s", resp.Name, datatankHandle)
+
+	d.Set("datatank_table_id", resp.Id)
+	d.Set("organization", orgHandle)
+	d.Set("workspace_handle", workspaceHandle)
+	d.Set("datatank_id", resp.DatatankId)
+	d.Set("datatank_handle", datatankHandle)
+	d.Set("name", resp.Name)
+	d.Set("migrating_name", resp.MigratingName)
+	d.Set("description", resp.Description)
+	d.Set("type", resp.Type)
+	d.Set("part_per", resp.PartPer)
+	d.Set("source_schema", resp.SourceSchema)
+	d.Set("source_table", resp.SourceTable)
+	d.Set("source_query", resp.SourceQuery)
+	d.Set("state", resp.State)
+	d.Set("state_reason", resp.StateReason)
+	d.Set("desired_state", resp.DesiredState)
+	d.Set("freshness", FormatJson(resp.Freshness))
+	d.Set("migrating_freshness", FormatJson(resp.MigratingFreshness))
+	d.Set("frequency", FormatJson(resp.Frequency))
+	d.Set("created_at", resp.CreatedAt)
+	d.Set("updated_at", resp.UpdatedAt)
+	if resp.CreatedBy != nil {
+		d.Set("created_by", resp.CreatedBy.Handle)
+	}
+	if resp.UpdatedBy != nil {
+		d.Set("updated_by", resp.UpdatedBy.Handle)
+	}
+	d.Set("version_id", resp.VersionId)
+
+	if isUser {
+		d.SetId(fmt.Sprintf("%s/%s/%s", workspaceHandle, datatankHandle, resp.Name))
+	} else {
+		d.SetId(fmt.Sprintf("%s/%s/%s/%s", orgHandle, workspaceHandle, datatankHandle, resp.Name))
+	}
+
+	return diags
+}
@@ -0,0 +1,187 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceWorkspaceDatatankTables() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWorkspaceDatatankTablesRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"workspace_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datatank_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"tables": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datatank_table_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"part_per": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_schema": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_table": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_query": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"desired_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"freshness": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"frequency": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceDatatankTablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.ListDatatankTableResponse
+	var r *http.Response
+	var err error
+	var actorHandle, nextToken, tfId string
+	var tables []pipes.DatatankTable
+
+	workspaceHandle := d.Get("workspace_handle").(string)
+	datatankHandle := d.Get("datatank_handle").(string)
+
+	client := meta.(*PipesClient)
+
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		actorHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceWorkspaceDatatankTablesRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		tfId = fmt.Sprintf("%s/%s", workspaceHandle, datatankHandle)
+	} else {
+		tfId = fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, datatankHandle)
+	}
+
+	// Page through all the tables of the datatank
+	for {
+		if isUser {
+			req := client.APIClient.UserWorkspaceDatatankTables.List(ctx, actorHandle, workspaceHandle, datatankHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		} else {
+			req := client.APIClient.OrgWorkspaceDatatankTables.List(ctx, orgHandle, workspaceHandle, datatankHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error listing workspace datatank tables: %v", decodeResponse(r))
+		}
+		if resp.Items != nil {
+			tables = append(tables, *resp.Items...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+	log.Printf("\n[DEBUG] %d tables received for Datatank: %s", len(tables), datatankHandle)
+
+	items := make([]map[string]interface{}, 0, len(tables))
+	for _, table := range tables {
+		items = append(items, map[string]interface{}{
+			"datatank_table_id": table.Id,
+			"name":              table.Name,
+			"description":       table.GetDescription(),
+			"type":              table.Type,
+			"part_per":          table.GetPartPer(),
+			"source_schema":     table.GetSourceSchema(),
+			"source_table":      table.GetSourceTable(),
+			"source_query":      table.GetSourceQuery(),
+			"state":             string(table.State),
+			"state_reason":      table.GetStateReason(),
+			"desired_state":     string(table.DesiredState),
+			"freshness":         FormatJson(table.Freshness),
+			"frequency":         FormatJson(table.Frequency),
+			"created_at":        table.CreatedAt,
+			"updated_at":        table.GetUpdatedAt(),
+			"version_id":        table.VersionId,
+		})
+	}
+
+	if err := d.Set("tables", items); err != nil {
+		return diag.Errorf("error setting tables: %v", err)
+	}
+	d.SetId(tfId)
+
+	return diags
+}
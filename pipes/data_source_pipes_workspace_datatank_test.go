@@ -0,0 +1,134 @@
+package pipes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserWorkspaceDatatankDataSource_Basic(t *testing.T) {
+	dataSourceName := "data.pipes_workspace_datatank.test"
+	listDataSourceName := "data.pipes_workspace_datatanks.test"
+	workspaceHandle := "workspace" + randomString(3)
+	datatankHandle := "fast_net"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspaceDatatankDataSourceConfig(workspaceHandle, datatankHandle),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "datatank_id", "pipes_workspace_datatank.test_datatank", "datatank_id"),
+					resource.TestCheckResourceAttr(dataSourceName, "handle", datatankHandle),
+					resource.TestCheckResourceAttrSet(dataSourceName, "state"),
+					resource.TestCheckResourceAttr(listDataSourceName, "datatanks.#", "1"),
+					resource.TestCheckResourceAttr(listDataSourceName, "datatanks.0.handle", datatankHandle),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserWorkspaceDatatankTableDataSource_Basic(t *testing.T) {
+	dataSourceName := "data.pipes_workspace_datatank_table.test"
+	listDataSourceName := "data.pipes_workspace_datatank_tables.test"
+	workspaceHandle := "workspace" + randomString(3)
+	datatankHandle := "fast_net"
+	name := "net_certificate"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspaceDatatankTableDataSourceConfig(workspaceHandle, datatankHandle, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "name", name),
+					resource.TestCheckResourceAttr(dataSourceName, "source_schema", "all_net"),
+					resource.TestCheckResourceAttr(dataSourceName, "source_table", "net_certificate"),
+					resource.TestCheckResourceAttr(dataSourceName, "part_per", "connection"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "freshness"),
+					resource.TestCheckResourceAttr(listDataSourceName, "tables.#", "1"),
+					resource.TestCheckResourceAttr(listDataSourceName, "tables.0.name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserWorkspaceDatatankDataSourceConfig(workspaceHandle, datatankHandle string) string {
+	return fmt.Sprintf(`
+resource "pipes_workspace" "test_workspace" {
+	handle = "%s"
+}
+
+resource "pipes_workspace_datatank" "test_datatank" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	handle           = "%s"
+}
+
+data "pipes_workspace_datatank" "test" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	handle           = pipes_workspace_datatank.test_datatank.handle
+}
+
+data "pipes_workspace_datatanks" "test" {
+	workspace_handle = pipes_workspace_datatank.test_datatank.workspace_handle
+}`, workspaceHandle, datatankHandle)
+}
+
+func testAccUserWorkspaceDatatankTableDataSourceConfig(workspaceHandle, datatankHandle, name string) string {
+	return fmt.Sprintf(`
+resource "pipes_workspace" "test_workspace" {
+	handle = "%s"
+}
+
+resource "pipes_connection" "test_connection_net" {
+	handle = "net_1"
+	plugin = "net"
+}
+
+resource "pipes_workspace_connection" "test_connection_net_association" {
+	workspace_handle  = pipes_workspace.test_workspace.handle
+	connection_handle = pipes_connection.test_connection_net.handle
+}
+
+resource "pipes_workspace_aggregator" "test_aggregator_all_net" {
+	workspace   = pipes_workspace.test_workspace.handle
+	handle      = "all_net"
+	plugin      = "net"
+	connections = ["*"]
+}
+
+resource "pipes_workspace_datatank" "test_datatank" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	handle           = "%s"
+}
+
+resource "pipes_workspace_datatank_table" "test_datatank_table" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	datatank_handle  = pipes_workspace_datatank.test_datatank.handle
+	name             = "%s"
+	type             = "table"
+	part_per         = "connection"
+	source_schema    = pipes_workspace_aggregator.test_aggregator_all_net.handle
+	source_table     = "net_certificate"
+	frequency = jsonencode({
+		type     = "interval"
+		schedule = "daily"
+	})
+}
+
+data "pipes_workspace_datatank_table" "test" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	datatank_handle  = pipes_workspace_datatank.test_datatank.handle
+	name             = pipes_workspace_datatank_table.test_datatank_table.name
+}
+
+data "pipes_workspace_datatank_tables" "test" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	datatank_handle  = pipes_workspace_datatank_table.test_datatank_table.datatank_handle
+}`, workspaceHandle, datatankHandle, name)
+}
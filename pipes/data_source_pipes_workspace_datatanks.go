@@ -0,0 +1,147 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceWorkspaceDatatanks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWorkspaceDatatanksRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"workspace_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"datatanks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datatank_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state_reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"desired_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceDatatanksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.ListDatatankResponse
+	var r *http.Response
+	var err error
+	var actorHandle, nextToken, tfId string
+	var datatanks []pipes.Datatank
+
+	workspaceHandle := d.Get("workspace_handle").(string)
+
+	client := meta.(*PipesClient)
+
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		actorHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceWorkspaceDatatanksRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		tfId = workspaceHandle
+	} else {
+		tfId = fmt.Sprintf("%s/%s", orgHandle, workspaceHandle)
+	}
+
+	// Page through all the datatanks of the workspace
+	for {
+		if isUser {
+			req := client.APIClient.UserWorkspaceDatatanks.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		} else {
+			req := client.APIClient.OrgWorkspaceDatatanks.List(ctx, orgHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error listing workspace datatanks: %v", decodeResponse(r))
+		}
+		if resp.Items != nil {
+			datatanks = append(datatanks, *resp.Items...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+	log.Printf("\n[DEBUG] %d datatanks received for Workspace: %s", len(datatanks), workspaceHandle)
+
+	items := make([]map[string]interface{}, 0, len(datatanks))
+	for _, datatank := range datatanks {
+		items = append(items, map[string]interface{}{
+			"datatank_id":   datatank.Id,
+			"handle":        datatank.Handle,
+			"description":   datatank.Description,
+			"state":         string(datatank.State),
+			"state_reason":  datatank.GetStateReason(),
+			"desired_state": string(datatank.DesiredState),
+			"created_at":    datatank.CreatedAt,
+			"updated_at":    datatank.GetUpdatedAt(),
+			"version_id":    datatank.VersionId,
+		})
+	}
+
+	if err := d.Set("datatanks", items); err != nil {
+		return diag.Errorf("error setting datatanks: %v", err)
+	}
+	d.SetId(tfId)
+
+	return diags
+}
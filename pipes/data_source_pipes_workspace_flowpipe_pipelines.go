@@ -0,0 +1,175 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceWorkspaceFlowpipePipelines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWorkspaceFlowpipePipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: false,
+			},
+			"workspace": {
+				Type:     schema.TypeString,
+				Required: true,
+				Computed: false,
+			},
+			"workspace_mod_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: false,
+			},
+			"pipelines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"workspace_mod_pipeline_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"workspace_mod_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"params": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"triggers": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_process_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceFlowpipePipelinesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.ListFlowpipePipelinesResponse
+	var r *http.Response
+	var err error
+	var userHandle, nextToken, tfId string
+	var pipelines []pipes.WorkspaceModPipeline
+
+	workspace := d.Get("workspace").(string)
+	workspaceModId := d.Get("workspace_mod_id").(string)
+
+	client := meta.(*PipesClient)
+
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		userHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceWorkspaceFlowpipePipelinesRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		tfId = workspace
+	} else {
+		tfId = fmt.Sprintf("%s/%s", orgHandle, workspace)
+	}
+
+	// Page through all the pipelines of the workspace
+	for {
+		if isUser {
+			req := client.APIClient.UserWorkspaceFlowpipePipelines.List(ctx, userHandle, workspace)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		} else {
+			req := client.APIClient.OrgWorkspaceFlowpipePipelines.List(ctx, orgHandle, workspace)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error listing workspace Flowpipe pipelines: %v", decodeResponse(r))
+		}
+		if resp.Items != nil {
+			pipelines = append(pipelines, *resp.Items...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+	log.Printf("\n[DEBUG] %d pipelines received for Workspace: %s", len(pipelines), workspace)
+
+	items := make([]map[string]interface{}, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		// Only return pipelines for the requested mod, if one was passed
+		if workspaceModId != "" && pipeline.GetWorkspaceModId() != workspaceModId {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			"workspace_mod_pipeline_id": pipeline.GetId(),
+			"workspace_mod_id":          pipeline.GetWorkspaceModId(),
+			"name":                      pipeline.GetName(),
+			"title":                     pipeline.GetTitle(),
+			"description":               pipeline.GetDescription(),
+			"params":                    FormatJson(pipeline.Params),
+			"tags":                      tagsToStringMap(pipeline.Tags),
+			"triggers":                  FormatJson(pipeline.Triggers),
+			"last_process_id":           pipeline.GetLastProcessId(),
+			"created_at":                pipeline.CreatedAt,
+			"updated_at":                pipeline.GetUpdatedAt(),
+			"version_id":                pipeline.VersionId,
+		})
+	}
+
+	if err := d.Set("pipelines", items); err != nil {
+		return diag.Errorf("error setting pipelines: %v", err)
+	}
+	d.SetId(tfId)
+
+	return diags
+}
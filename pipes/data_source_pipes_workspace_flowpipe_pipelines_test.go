@@ -0,0 +1,84 @@
+package pipes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserWorkspaceFlowpipePipelinesDataSource_Basic(t *testing.T) {
+	dataSourceName := "data.pipes_workspace_flowpipe_pipelines.test"
+	workspaceHandle := "ws" + randomString(3)
+	modPath := "github.com/turbot/flowpipe-mod-aws-thrifty"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspaceFlowpipePipelinesDataSourceConfig(workspaceHandle, modPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "workspace", workspaceHandle),
+					resource.TestCheckResourceAttrSet(dataSourceName, "pipelines.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "pipelines.0.workspace_mod_pipeline_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipelines.0.workspace_mod_id", "pipes_workspace_flowpipe_mod.aws_thrifty", "workspace_mod_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserWorkspaceFlowpipeTriggersDataSource_Basic(t *testing.T) {
+	dataSourceName := "data.pipes_workspace_flowpipe_triggers.test"
+	workspaceHandle := "ws" + randomString(3)
+	modPath := "github.com/turbot/flowpipe-mod-aws-thrifty"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspaceFlowpipeTriggersDataSourceConfig(workspaceHandle, modPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "workspace", workspaceHandle),
+					resource.TestCheckResourceAttrSet(dataSourceName, "triggers.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserWorkspaceFlowpipePipelinesDataSourceConfig(wsHandle, modPath string) string {
+	return fmt.Sprintf(`
+resource "pipes_workspace" "test_workspace" {
+	handle = "%s"
+}
+
+resource "pipes_workspace_flowpipe_mod" "aws_thrifty" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	path = "%s"
+}
+
+data "pipes_workspace_flowpipe_pipelines" "test" {
+	workspace = pipes_workspace.test_workspace.handle
+	workspace_mod_id = pipes_workspace_flowpipe_mod.aws_thrifty.workspace_mod_id
+}`, wsHandle, modPath)
+}
+
+func testAccUserWorkspaceFlowpipeTriggersDataSourceConfig(wsHandle, modPath string) string {
+	return fmt.Sprintf(`
+resource "pipes_workspace" "test_workspace" {
+	handle = "%s"
+}
+
+resource "pipes_workspace_flowpipe_mod" "aws_thrifty" {
+	workspace_handle = pipes_workspace.test_workspace.handle
+	path = "%s"
+}
+
+data "pipes_workspace_flowpipe_triggers" "test" {
+	workspace = pipes_workspace.test_workspace.handle
+	workspace_mod_id = pipes_workspace_flowpipe_mod.aws_thrifty.workspace_mod_id
+}`, wsHandle, modPath)
+}
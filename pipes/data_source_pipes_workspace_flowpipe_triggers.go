@@ -0,0 +1,190 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func dataSourceWorkspaceFlowpipeTriggers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWorkspaceFlowpipeTriggersRead,
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: false,
+			},
+			"workspace": {
+				Type:     schema.TypeString,
+				Required: true,
+				Computed: false,
+			},
+			"workspace_mod_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: false,
+			},
+			"triggers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"trigger_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"workspace_mod_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"schedule": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"query": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"params": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pipelines": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceFlowpipeTriggersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var resp pipes.ListTriggersResponse
+	var r *http.Response
+	var err error
+	var userHandle, nextToken, tfId string
+	var triggers []pipes.ModTriggerInfo
+
+	workspace := d.Get("workspace").(string)
+	workspaceModId := d.Get("workspace_mod_id").(string)
+
+	client := meta.(*PipesClient)
+
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		userHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("dataSourceWorkspaceFlowpipeTriggersRead.getUserHandler error  %v", decodeResponse(r))
+		}
+		tfId = workspace
+	} else {
+		tfId = fmt.Sprintf("%s/%s", orgHandle, workspace)
+	}
+
+	// Page through all the triggers of the workspace
+	for {
+		if isUser {
+			req := client.APIClient.UserWorkspaceFlowpipeTriggers.List(ctx, userHandle, workspace)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		} else {
+			req := client.APIClient.OrgWorkspaceFlowpipeTriggers.List(ctx, orgHandle, workspace)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error listing workspace Flowpipe triggers: %v", decodeResponse(r))
+		}
+		if resp.Items != nil {
+			triggers = append(triggers, *resp.Items...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+	log.Printf("\n[DEBUG] %d triggers received for Workspace: %s", len(triggers), workspace)
+
+	items := make([]map[string]interface{}, 0, len(triggers))
+	for _, trigger := range triggers {
+		// Only return triggers for the requested mod, if one was passed
+		if workspaceModId != "" && trigger.GetModId() != workspaceModId {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			"trigger_id":       trigger.GetId(),
+			"workspace_mod_id": trigger.GetModId(),
+			"name":             trigger.GetName(),
+			"title":            trigger.GetTitle(),
+			"description":      trigger.GetDescription(),
+			"type":             trigger.GetType(),
+			"schedule":         trigger.GetSchedule(),
+			"query":            trigger.GetQuery(),
+			"state":            string(trigger.GetState()),
+			"params":           FormatJson(trigger.Params),
+			"pipelines":        FormatJson(trigger.Pipelines),
+			"tags":             tagsToStringMap(trigger.Tags),
+			"created_at":       trigger.CreatedAt,
+			"updated_at":       trigger.GetUpdatedAt(),
+			"version_id":       trigger.VersionId,
+		})
+	}
+
+	if err := d.Set("triggers", items); err != nil {
+		return diag.Errorf("error setting triggers: %v", err)
+	}
+	d.SetId(tfId)
+
+	return diags
+}
@@ -0,0 +1,151 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceIdFormat:: The formats accepted for the ID of a resource, e.g. "<workspace-handle>/<mod-alias>" and
+// "<org-handle>/<workspace-handle>/<mod-alias>". The parts of an ID are separated by a /, and each format of a
+// resource has a different number of parts, so that an ID matches at most one of them. A part without angle brackets,
+// e.g. the "preferences" of "<user-handle>/preferences", must appear as-is in the ID.
+type resourceIdFormat struct {
+	// The parts of each format, with the angle brackets of the named parts
+	formats [][]string
+	// For backward-compatibility, the parts of the IDs of some resources may also be separated by a :
+	legacySeparator string
+}
+
+// resourceId:: The parts of a parsed ID, by name
+type resourceId map[string]string
+
+func newResourceIdFormat(formats ...string) resourceIdFormat {
+	var f resourceIdFormat
+	lengths := map[int]bool{}
+	for _, format := range formats {
+		parts := strings.Split(format, "/")
+		if lengths[len(parts)] {
+			panic(fmt.Sprintf("ambiguous ID format %q, another format has %d parts", format, len(parts)))
+		}
+		lengths[len(parts)] = true
+		f.formats = append(f.formats, parts)
+	}
+	return f
+}
+
+// withLegacySeparator:: Also accept IDs whose parts are separated by the given separator
+func (f resourceIdFormat) withLegacySeparator(separator string) resourceIdFormat {
+	f.legacySeparator = separator
+	return f
+}
+
+// workspaceResourceIdFormat:: The formats of the ID of a resource of a user or an organization workspace, i.e.
+// "<workspace-handle>/..." and "<org-handle>/<workspace-handle>/..." followed by the given parts
+func workspaceResourceIdFormat(parts ...string) resourceIdFormat {
+	format := strings.Join(append([]string{"<workspace-handle>"}, parts...), "/")
+	return newResourceIdFormat(format, "<org-handle>/"+format).withLegacySeparator(":")
+}
+
+// parse:: The parts of an ID, or an error listing the accepted formats when the ID matches none of them
+func (f resourceIdFormat) parse(id string) (resourceId, error) {
+	separator := "/"
+	if f.legacySeparator != "" && !strings.Contains(id, "/") && strings.Contains(id, f.legacySeparator) {
+		separator = f.legacySeparator
+	}
+	parts := strings.Split(id, separator)
+
+	for _, format := range f.formats {
+		if len(format) != len(parts) {
+			continue
+		}
+		parsed := resourceId{}
+		for i, part := range format {
+			name, ok := idPartName(part)
+			if !ok {
+				if parts[i] != part {
+					return nil, fmt.Errorf("invalid ID %q: %q should be %q, expected %s", id, parts[i], part, f)
+				}
+				continue
+			}
+			if strings.TrimSpace(parts[i]) == "" {
+				return nil, fmt.Errorf("invalid ID %q: %s is empty, expected %s", id, part, f)
+			}
+			parsed[name] = parts[i]
+		}
+		return parsed, nil
+	}
+	return nil, fmt.Errorf("invalid ID %q, expected %s", id, f)
+}
+
+// format:: The ID of the given parts, separated by a /, in the format with the same parts, e.g. to rewrite an ID
+// parsed with the legacy separator. Empty parts are left out, so that the format without the organization is used for
+// a workspace of a user. The ID is empty if no format has the same parts.
+func (f resourceIdFormat) format(id resourceId) string {
+	count := 0
+	for _, value := range id {
+		if value != "" {
+			count++
+		}
+	}
+	for _, format := range f.formats {
+		parts := make([]string, len(format))
+		named := 0
+		for i, part := range format {
+			parts[i] = part
+			if name, ok := idPartName(part); ok {
+				parts[i] = id[name]
+				named++
+			}
+		}
+		if named == count && !missesParts(format, id) {
+			return strings.Join(parts, "/")
+		}
+	}
+	return ""
+}
+
+// missesParts:: Whether a named part of a format is empty in the given ID
+func missesParts(format []string, id resourceId) bool {
+	for _, part := range format {
+		if name, ok := idPartName(part); ok && id[name] == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// String:: The accepted formats, e.g. "<workspace-handle>/<mod-alias>" or "<org-handle>/<workspace-handle>/<mod-alias>"
+func (f resourceIdFormat) String() string {
+	formats := make([]string, len(f.formats))
+	for i, format := range f.formats {
+		formats[i] = fmt.Sprintf("%q", strings.Join(format, "/"))
+	}
+	return strings.Join(formats, " or ")
+}
+
+// idPartName:: The name of a part of an ID format between angle brackets, or false for a literal part
+func idPartName(part string) (string, bool) {
+	if strings.HasPrefix(part, "<") && strings.HasSuffix(part, ">") {
+		return strings.TrimSuffix(strings.TrimPrefix(part, "<"), ">"), true
+	}
+	return "", false
+}
+
+// resourceIdImporter:: Import a resource by its ID, which must match one of the given formats. The organizations, users
+// and workspaces of the ID may be given by their IDs instead of their handles, and the resolvers look up the handles of
+// the other objects given by ID. The ID is then set to the canonical ID of the resource, with handles separated by /.
+func resourceIdImporter(f resourceIdFormat, resolvers ...resourceIdResolver) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			id, err := resolveResourceId(ctx, meta.(*PipesClient), f, d.Id(), resolvers...)
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(f.format(id))
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
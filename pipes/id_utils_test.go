@@ -0,0 +1,203 @@
+package pipes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResourceIdFormatParse(t *testing.T) {
+	modFormat := workspaceResourceIdFormat("<mod-alias>")
+
+	cases := []struct {
+		format   resourceIdFormat
+		id       string
+		expected resourceId
+		err      string
+	}{
+		{modFormat, "myworkspace/mymod", resourceId{"workspace-handle": "myworkspace", "mod-alias": "mymod"}, ""},
+		{modFormat, "myorg/myworkspace/mymod", resourceId{"org-handle": "myorg", "workspace-handle": "myworkspace", "mod-alias": "mymod"}, ""},
+		{modFormat, "myorg:myworkspace:mymod", resourceId{"org-handle": "myorg", "workspace-handle": "myworkspace", "mod-alias": "mymod"}, ""},
+		{modFormat, "myworkspace", nil, `invalid ID "myworkspace", expected "<workspace-handle>/<mod-alias>" or "<org-handle>/<workspace-handle>/<mod-alias>"`},
+		{modFormat, "a/b/c/d", nil, `invalid ID "a/b/c/d", expected`},
+		{modFormat, "", nil, `invalid ID "", expected`},
+		{modFormat, "myorg//mymod", nil, `invalid ID "myorg//mymod": <workspace-handle> is empty`},
+		{modFormat, "myworkspace/ ", nil, `invalid ID "myworkspace/ ": <mod-alias> is empty`},
+		// The legacy separator is only used when the ID has no /
+		{modFormat, "myorg:myworkspace/mymod", resourceId{"workspace-handle": "myorg:myworkspace", "mod-alias": "mymod"}, ""},
+		{workspaceResourceIdFormat(), "myworkspace", resourceId{"workspace-handle": "myworkspace"}, ""},
+		{workspaceResourceIdFormat(), "myorg/myworkspace", resourceId{"org-handle": "myorg", "workspace-handle": "myworkspace"}, ""},
+		{newResourceIdFormat("<org-handle>/<connection-handle>"), "myorg:myconn", nil, `invalid ID "myorg:myconn", expected "<org-handle>/<connection-handle>"`},
+		{newResourceIdFormat("<user-handle>/preferences"), "me/preferences", resourceId{"user-handle": "me"}, ""},
+		{newResourceIdFormat("<user-handle>/preferences"), "me/settings", nil, `invalid ID "me/settings": "settings" should be "preferences"`},
+		{newResourceIdFormat("tenant/settings"), "tenant/settings", resourceId{}, ""},
+	}
+
+	for _, c := range cases {
+		parsed, err := c.format.parse(c.id)
+		if c.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), c.err) {
+				t.Errorf("parse(%q): expected error starting with %q, got %v", c.id, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parse(%q): unexpected error %v", c.id, err)
+			continue
+		}
+		if !reflect.DeepEqual(parsed, c.expected) {
+			t.Errorf("parse(%q): expected %v, got %v", c.id, c.expected, parsed)
+		}
+	}
+}
+
+func TestResourceIdFormatFormat(t *testing.T) {
+	format := workspaceResourceIdFormat("<mod-alias>", "<variable-name>")
+
+	cases := map[string]string{
+		"myworkspace/mymod/myvar":       "myworkspace/mymod/myvar",
+		"myorg/myworkspace/mymod/myvar": "myorg/myworkspace/mymod/myvar",
+		"myorg:myworkspace:mymod:myvar": "myorg/myworkspace/mymod/myvar",
+	}
+	for id, expected := range cases {
+		parsed, err := format.parse(id)
+		if err != nil {
+			t.Errorf("parse(%q): unexpected error %v", id, err)
+			continue
+		}
+		if actual := format.format(parsed); actual != expected {
+			t.Errorf("format(parse(%q)): expected %q, got %q", id, expected, actual)
+		}
+	}
+
+	if actual := newResourceIdFormat("<user-handle>/preferences").format(resourceId{"user-handle": "me"}); actual != "me/preferences" {
+		t.Errorf("format: expected %q, got %q", "me/preferences", actual)
+	}
+}
+
+func TestResourceIdFormatAmbiguous(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for formats with the same number of parts")
+		}
+	}()
+	newResourceIdFormat("<org-handle>/<connection-handle>", "<tenant-id>/<connection-handle>")
+}
+
+func FuzzResourceIdFormatParse(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"/",
+		"::",
+		"myworkspace/mymod",
+		"myorg/myworkspace/mymod",
+		"myorg:myworkspace:mymod",
+		"myorg:myworkspace/mymod",
+		"myorg//mymod",
+		"a/b/c/d/e",
+		" / / ",
+	} {
+		f.Add(seed)
+	}
+
+	format := workspaceResourceIdFormat("<mod-alias>")
+	f.Fuzz(func(t *testing.T, id string) {
+		parsed, err := format.parse(id)
+		if err != nil {
+			if !strings.Contains(err.Error(), format.String()) {
+				t.Errorf("parse(%q): error %q does not list the accepted formats", id, err)
+			}
+			return
+		}
+		for name, part := range parsed {
+			if strings.TrimSpace(part) == "" {
+				t.Errorf("parse(%q): %s is empty", id, name)
+			}
+		}
+		// A parsed ID is formatted back to the same ID, with the legacy separator replaced
+		formatted := format.format(parsed)
+		if !strings.Contains(id, "/") {
+			id = strings.ReplaceAll(id, ":", "/")
+		}
+		if formatted != id {
+			t.Errorf("format(parse(%q)): got %q", id, formatted)
+		}
+		if reparsed, err := format.parse(formatted); err != nil || !reflect.DeepEqual(reparsed, parsed) {
+			t.Errorf("parse(format(parse(%q))): got %v, %v", id, reparsed, err)
+		}
+	})
+}
+
+func TestIsPipesId(t *testing.T) {
+	cases := []struct {
+		value    string
+		prefix   string
+		expected bool
+	}{
+		{"o_cdi2d6c3ul7ugl1l6e30", "o", true},
+		{"c_cdi2d6c3ul7ugl1l6e30", "c", true},
+		{"c_cdi2d6c3ul7ugl1l6e30", "o", false},
+		{"c_", "c", false},
+		{"c_prod_aws", "c", false},
+		{"c_Prod", "c", false},
+		{"myorg", "o", false},
+		{"", "o", false},
+	}
+	for _, c := range cases {
+		if actual := isPipesId(c.value, c.prefix); actual != c.expected {
+			t.Errorf("isPipesId(%q, %q): expected %t, got %t", c.value, c.prefix, c.expected, actual)
+		}
+	}
+}
+
+func TestResourceIdFormatFormatResolved(t *testing.T) {
+	// A workspace imported by its ID is resolved to a workspace of the user, without organization
+	format := workspaceResourceIdFormat("<mod-alias>")
+	if actual := format.format(resourceId{"org-handle": "", "workspace-handle": "myworkspace", "mod-alias": "mymod"}); actual != "myworkspace/mymod" {
+		t.Errorf("format: expected %q, got %q", "myworkspace/mymod", actual)
+	}
+
+	// A connection imported by its ID alone is resolved to the canonical format
+	format = newResourceIdFormat("<connection-id>", "<org-handle>/<connection-handle>")
+	if actual := format.format(resourceId{"org-handle": "myorg", "connection-handle": "myconn"}); actual != "myorg/myconn" {
+		t.Errorf("format: expected %q, got %q", "myorg/myconn", actual)
+	}
+	if actual := format.format(resourceId{"org-handle": "myorg"}); actual != "" {
+		t.Errorf("format: expected no ID for missing parts, got %q", actual)
+	}
+}
+
+func TestImportedConnectionFolderId(t *testing.T) {
+	format := newResourceIdFormat("<folder-id>", "<org-handle>/<connection-folder-id>")
+	cases := map[string]struct {
+		id       string
+		folderId string
+		alone    bool
+		err      bool
+	}{
+		"alone":             {id: "c_cqlp0647sic7l5q2n5d0", folderId: "c_cqlp0647sic7l5q2n5d0", alone: true},
+		"alone with f_":     {id: "f_cqlp0647sic7l5q2n5d0", folderId: "f_cqlp0647sic7l5q2n5d0", alone: true},
+		"with scope":        {id: "myorg/c_cqlp0647sic7l5q2n5d0"},
+		"not an ID":         {id: "myfolder", err: true},
+		"other object's ID": {id: "w_cqlp0647sic7l5q2n5d0", err: true},
+	}
+	for name, c := range cases {
+		id, err := format.parse(c.id)
+		if err != nil {
+			t.Fatalf("%s: parse: %v", name, err)
+		}
+		folderId, alone, err := importedConnectionFolderId(id)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil || folderId != c.folderId || alone != c.alone {
+			t.Errorf("%s: expected %q, %t, got %q, %t, %v", name, c.folderId, c.alone, folderId, alone, err)
+		}
+		if _, ok := id["folder-id"]; ok {
+			t.Errorf("%s: expected the folder ID to be removed from the parsed ID", name)
+		}
+	}
+}
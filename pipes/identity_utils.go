@@ -0,0 +1,136 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// identityAttributes:: The identity attributes of the named parts of an ID format, e.g. "org_handle" for
+// "<org-handle>", and whether the attribute is required, i.e. whether the part is in every format of the ID
+func (f resourceIdFormat) identityAttributes() map[string]bool {
+	counts := map[string]int{}
+	for _, format := range f.formats {
+		for _, part := range format {
+			if name, ok := idPartName(part); ok {
+				counts[name]++
+			}
+		}
+	}
+	attributes := map[string]bool{}
+	for name, count := range counts {
+		attributes[identityAttributeName(name)] = count == len(f.formats)
+	}
+	return attributes
+}
+
+// identityAttributeName:: The name of the identity attribute of a named part of an ID, e.g. "org_handle" for "org-handle"
+func identityAttributeName(part string) string {
+	return strings.ReplaceAll(part, "-", "_")
+}
+
+// resourceIdentity:: The identity of a resource, made up of the named parts of its ID
+func resourceIdentity(f resourceIdFormat) *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			attributes := map[string]*schema.Schema{}
+			for name, required := range f.identityAttributes() {
+				attributes[name] = &schema.Schema{
+					Type:              schema.TypeString,
+					RequiredForImport: required,
+					OptionalForImport: !required,
+				}
+			}
+			return attributes
+		},
+	}
+}
+
+// identityValues:: The values of the identity attributes of a resource ID. The attributes of the parts missing from
+// the ID, e.g. the organization of a workspace of the user, are left null.
+func identityValues(id resourceId) map[string]string {
+	values := map[string]string{}
+	for part, value := range id {
+		if value != "" {
+			values[identityAttributeName(part)] = value
+		}
+	}
+	return values
+}
+
+// withIdentity:: Add an identity to a resource, made up of the named parts of its ID, so that the resource can be
+// listed and imported by its identity. The identity is set from the ID after every create, read and update, and an
+// import by identity is turned into an import by the ID of the identity. The identity may change, as the handles
+// in the ID of most resources can be renamed.
+func withIdentity(r *schema.Resource, f resourceIdFormat) *schema.Resource {
+	r.Identity = resourceIdentity(f)
+	r.ResourceBehavior.MutableIdentity = true
+
+	create, read, update := r.CreateContext, r.ReadContext, r.UpdateContext
+	r.CreateContext = setIdentityAfter(create, f)
+	r.ReadContext = setIdentityAfter(read, f)
+	if update != nil {
+		r.UpdateContext = setIdentityAfter(update, f)
+	}
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer = &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if d.Id() == "" {
+					id, err := identityId(d, f)
+					if err != nil {
+						return nil, err
+					}
+					d.SetId(id)
+				}
+				return importState(ctx, d, meta)
+			},
+		}
+	}
+	return r
+}
+
+// setIdentityAfter:: Set the identity of a resource from its ID after the given create, read or update function
+func setIdentityAfter[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](fn F, f resourceIdFormat) F {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := fn(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		id, err := f.parse(d.Id())
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		identity, err := d.Identity()
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		for name, value := range identityValues(id) {
+			if err := identity.Set(name, value); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+		return diags
+	}
+}
+
+// identityId:: The ID of a resource imported by its identity
+func identityId(d *schema.ResourceData, f resourceIdFormat) (string, error) {
+	identity, err := d.Identity()
+	if err != nil {
+		return "", err
+	}
+	id := resourceId{}
+	for name := range f.identityAttributes() {
+		id[strings.ReplaceAll(name, "_", "-")] = identity.Get(name).(string)
+	}
+	formatted := f.format(id)
+	if formatted == "" {
+		return "", fmt.Errorf("invalid identity, expected the parts of %s", f)
+	}
+	return formatted, nil
+}
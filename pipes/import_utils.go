@@ -0,0 +1,163 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+// resourceIdResolver:: Look up the handles of the objects of an imported ID given by their IDs instead, e.g. "c_..." for a
+// connection, and the parts missing from the ID, e.g. the workspace of a connection imported by its ID alone
+type resourceIdResolver func(ctx context.Context, client *PipesClient, id resourceId) error
+
+// isPipesId:: Whether a value is the ID of a Pipes object with the given prefix, e.g. "o_cdi2d6c3ul7ugl1l6e30" for
+// the prefix "o" of organizations, rather than its handle
+func isPipesId(value, prefix string) bool {
+	rest, ok := strings.CutPrefix(value, prefix+"_")
+	if !ok || rest == "" {
+		return false
+	}
+	for _, c := range rest {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveResourceId:: Parse an imported ID with the given format, and resolve the objects given by their IDs. The
+// organizations, users and workspaces are resolved for every resource, before the resolvers of the resource.
+func resolveResourceId(ctx context.Context, client *PipesClient, f resourceIdFormat, rawId string, resolvers ...resourceIdResolver) (resourceId, error) {
+	id, err := f.parse(rawId)
+	if err != nil {
+		return nil, err
+	}
+	for _, resolve := range append([]resourceIdResolver{resolveIdentityIds, resolveWorkspaceId}, resolvers...) {
+		if err := resolve(ctx, client, id); err != nil {
+			return nil, err
+		}
+	}
+	if f.format(id) == "" {
+		return nil, fmt.Errorf("invalid ID %q, expected %s", rawId, f)
+	}
+	return id, nil
+}
+
+// resolveIdentityIds:: Replace the IDs of the organization and the user of an imported ID by their handles
+func resolveIdentityIds(ctx context.Context, client *PipesClient, id resourceId) error {
+	for name, prefix := range map[string]string{"org-handle": "o", "user-handle": "u"} {
+		if !isPipesId(id[name], prefix) {
+			continue
+		}
+		identity, r, err := client.APIClient.Identities.Get(ctx, id[name]).Execute()
+		if err = resolveError(id[name], r, err); err != nil {
+			return err
+		}
+		if identity.Handle != "" {
+			id[name] = identity.Handle
+		}
+	}
+	return nil
+}
+
+// resolveWorkspaceId:: Replace the ID of the workspace of an imported ID by its handle, and set the organization of the
+// workspace, or remove it for a workspace of the user
+func resolveWorkspaceId(ctx context.Context, client *PipesClient, id resourceId) error {
+	if !isPipesId(id["workspace-handle"], "w") {
+		return nil
+	}
+	return setWorkspaceFromId(ctx, client, id, id["workspace-handle"])
+}
+
+// setWorkspaceFromId:: Set the workspace and the organization of an imported ID to those of the workspace with the given
+// ID, looked up amongst the workspaces of the actor
+func setWorkspaceFromId(ctx context.Context, client *PipesClient, id resourceId, workspaceId string) error {
+	var nextToken string
+	for {
+		req := client.APIClient.Actors.ListWorkspaces(ctx)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		if err != nil {
+			return fmt.Errorf("error listing the workspaces of the actor: %v", decodeResponse(r))
+		}
+		for _, item := range resp.GetItems() {
+			if item.GetWorkspaceId() != workspaceId && item.GetId() != workspaceId {
+				continue
+			}
+			id["workspace-handle"] = item.GetHandle()
+			if identity := item.GetIdentity(); identity.Type == "org" {
+				id["org-handle"] = identity.Handle
+			} else {
+				delete(id, "org-handle")
+			}
+			return nil
+		}
+		nextToken = resp.GetNextToken()
+		if nextToken == "" {
+			return fmt.Errorf("workspace %s not found", workspaceId)
+		}
+	}
+}
+
+// findActorConnection:: The connection with the given ID, looked up amongst the connections of the actor in any scope
+func findActorConnection(ctx context.Context, client *PipesClient, connectionId string) (pipes.Connection, error) {
+	var nextToken string
+	for {
+		req := client.APIClient.Actors.ListConnections(ctx)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		if err != nil {
+			return pipes.Connection{}, fmt.Errorf("error listing the connections of the actor: %v", decodeResponse(r))
+		}
+		for _, item := range resp.GetItems() {
+			if item.Id == connectionId {
+				return item, nil
+			}
+		}
+		nextToken = resp.GetNextToken()
+		if nextToken == "" {
+			return pipes.Connection{}, fmt.Errorf("connection %s not found", connectionId)
+		}
+	}
+}
+
+// importedConnectionFolderId:: The ID of a connection folder imported by its ID alone, removed from the parsed ID so
+// that the parts looked up can take its place, or false when the folder was imported with its scope
+func importedConnectionFolderId(id resourceId) (string, bool, error) {
+	folderId, ok := id["folder-id"]
+	if !ok {
+		return "", false, nil
+	}
+	if !isPipesId(folderId, "c") && !isPipesId(folderId, "f") {
+		return "", false, fmt.Errorf("invalid ID %q, a connection folder imported without its scope must be given by its ID, e.g. c_cqlp0647sic7l5q2n5d0", folderId)
+	}
+	delete(id, "folder-id")
+	return folderId, true, nil
+}
+
+// resolveError:: The error of the lookup of an object given by its ID. An object which is not found is not an error, as
+// a handle may look like an ID, and the handle is then kept.
+func resolveError(value string, r *http.Response, err error) error {
+	if err != nil && r != nil && r.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return lookupError(value, r, err)
+}
+
+// lookupError:: The error of the lookup of an object given by its ID, which must exist
+func lookupError(value string, r *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	if r == nil {
+		return err
+	}
+	return fmt.Errorf("error resolving %s: %v", value, decodeResponse(r))
+}
@@ -0,0 +1,470 @@
+package pipes
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/turbot/pipes-sdk-go"
+)
+
+// listResources:: The list resources of the provider, by the type of the resource they list
+func listResources() map[string]listResource {
+	return map[string]listResource{
+		"pipes_workspace": {
+			arguments: map[string]*schema.Schema{
+				"organization": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The handle of the organization to list the workspaces of. The workspaces of the user are listed by default.",
+				},
+			},
+			idFormat: workspaceIdFormat,
+			list:     listWorkspaceIds,
+		},
+		"pipes_organization_connection": {
+			arguments: organizationListArguments("connections"),
+			idFormat:  organizationConnectionIdFormat,
+			list:      listOrganizationConnectionIds,
+		},
+		"pipes_tenant_connection": {
+			idFormat: tenantConnectionIdFormat,
+			list:     listTenantConnectionIds,
+		},
+		"pipes_workspace_connection": {
+			arguments: workspaceListArguments("connections"),
+			idFormat:  workspaceConnectionIdFormat,
+			list:      listWorkspaceConnectionIds,
+		},
+		"pipes_organization_connection_folder": {
+			arguments: organizationListArguments("connection folders"),
+			idFormat:  organizationConnectionFolderIdFormat,
+			list:      listOrganizationConnectionFolderIds,
+		},
+		"pipes_tenant_connection_folder": {
+			idFormat: tenantConnectionFolderIdFormat,
+			list:     listTenantConnectionFolderIds,
+		},
+		"pipes_workspace_connection_folder": {
+			arguments: workspaceListArguments("connection folders"),
+			idFormat:  workspaceConnectionFolderIdFormat,
+			list:      listWorkspaceConnectionFolderIds,
+		},
+		"pipes_organization_member": {
+			arguments: organizationListArguments("members"),
+			idFormat:  organizationMemberIdFormat,
+			list:      listOrganizationMemberIds,
+		},
+		"pipes_organization_workspace_member": {
+			arguments: map[string]*schema.Schema{
+				"organization": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The handle of the organization of the workspace.",
+				},
+				"workspace": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The handle of the workspace to list the members of.",
+				},
+			},
+			idFormat: organizationWorkspaceMemberIdFormat,
+			list:     listOrganizationWorkspaceMemberIds,
+		},
+		"pipes_tenant_member": {
+			arguments: map[string]*schema.Schema{
+				"tenant_handle": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The handle of the tenant to list the members of.",
+				},
+			},
+			idFormat: tenantMemberIdFormat,
+			list:     listTenantMemberIds,
+		},
+		"pipes_tenant_notifier": {
+			idFormat: tenantNotifierIdFormat,
+			list:     listTenantNotifierIds,
+		},
+		"pipes_organization_notifier": {
+			arguments: organizationListArguments("notifiers"),
+			idFormat:  organizationNotifierIdFormat,
+			list:      listOrganizationNotifierIds,
+		},
+		"pipes_user_notifier": {
+			arguments: map[string]*schema.Schema{
+				"user_handle": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The handle of the user to list the notifiers of. The notifiers of the user of the token are listed by default.",
+				},
+			},
+			idFormat: userNotifierIdFormat,
+			list:     listUserNotifierIds,
+		},
+		"pipes_workspace_notifier": {
+			arguments: workspaceListArguments("notifiers"),
+			idFormat:  workspaceNotifierIdFormat,
+			list:      listWorkspaceNotifierIds,
+		},
+		"pipes_workspace_mod": {
+			arguments: workspaceListArguments("mods"),
+			idFormat:  workspaceModIdFormat,
+			list:      listWorkspaceModIds,
+		},
+		"pipes_workspace_flowpipe_trigger": {
+			arguments: workspaceListArguments("flowpipe triggers"),
+			idFormat:  workspaceFlowpipeTriggerIdFormat,
+			list:      listWorkspaceFlowpipeTriggerIds,
+		},
+	}
+}
+
+// organizationListArguments:: The arguments of the list block of the resources of an organization
+func organizationListArguments(objects string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"organization": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The handle of the organization to list the " + objects + " of.",
+		},
+	}
+}
+
+// workspaceListArguments:: The arguments of the list block of the resources of a user or an organization workspace
+func workspaceListArguments(objects string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"organization": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The handle of the organization of the workspace, for a workspace of an organization.",
+		},
+		"workspace": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The handle of the workspace to list the " + objects + " of.",
+		},
+	}
+}
+
+// workspaceListScope:: The workspace of the resources listed with the arguments of workspaceListArguments
+func workspaceListScope(args map[string]string) workspaceScope {
+	return workspaceScope{
+		organization: args["organization"],
+		workspace:    args["workspace"],
+	}
+}
+
+func listWorkspaceIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	scope := workspaceScope{organization: args["organization"]}
+	return listPages(func(nextToken string) ([]pipes.Workspace, *string, *http.Response, error) {
+		resp, r, err := workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.ListWorkspacesResponse, *http.Response, error) {
+				req := client.APIClient.UserWorkspaces.List(ctx, userHandle)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			},
+			func(orgHandle string) (pipes.ListWorkspacesResponse, *http.Response, error) {
+				req := client.APIClient.OrgWorkspaces.List(ctx, orgHandle)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			})
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(workspace pipes.Workspace) bool {
+		scope.workspace = workspace.Handle
+		return yield(scope.resourceId(), workspace.Handle)
+	})
+}
+
+func listOrganizationConnectionIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	orgHandle := args["organization"]
+	return listPages(func(nextToken string) ([]pipes.Connection, *string, *http.Response, error) {
+		req := client.APIClient.OrgConnections.List(ctx, orgHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(conn pipes.Connection) bool {
+		return yield(resourceId{"org-handle": orgHandle, "connection-handle": conn.GetHandle()}, conn.GetHandle())
+	})
+}
+
+func listTenantConnectionIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	return listPages(func(nextToken string) ([]pipes.Connection, *string, *http.Response, error) {
+		req := client.APIClient.TenantConnections.List(ctx)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(conn pipes.Connection) bool {
+		return yield(resourceId{"tenant-id": conn.TenantId, "connection-handle": conn.GetHandle()}, conn.GetHandle())
+	})
+}
+
+func listWorkspaceConnectionIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	scope := workspaceListScope(args)
+	// The connections of the organization or the tenant shared with the workspace are listed too, and are left out
+	return listPages(func(nextToken string) ([]string, *string, *http.Response, error) {
+		var handles []string
+		next, r, err := workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (*string, *http.Response, error) {
+				req := client.APIClient.UserWorkspaceConnections.List(ctx, userHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				resp, r, err := req.Execute()
+				for _, conn := range resp.GetItems() {
+					if conn.GetWorkspaceId() != "" {
+						handles = append(handles, conn.GetHandle())
+					}
+				}
+				return resp.NextToken, r, err
+			},
+			func(orgHandle string) (*string, *http.Response, error) {
+				req := client.APIClient.OrgWorkspaceConnections.List(ctx, orgHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				resp, r, err := req.Execute()
+				for _, item := range resp.GetItems() {
+					if conn := item.GetConnection(); conn.GetWorkspaceId() != "" {
+						handles = append(handles, conn.GetHandle())
+					}
+				}
+				return resp.NextToken, r, err
+			})
+		return handles, next, r, err
+	}, func(handle string) bool {
+		return yield(scope.resourceId("connection-handle", handle), handle)
+	})
+}
+
+func listOrganizationConnectionFolderIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	orgHandle := args["organization"]
+	return listPages(func(nextToken string) ([]pipes.Connection, *string, *http.Response, error) {
+		req := client.APIClient.OrgConnectionFolders.List(ctx, orgHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(folder pipes.Connection) bool {
+		return yield(resourceId{"org-handle": orgHandle, "connection-folder-id": folder.Id}, folder.GetTitle())
+	})
+}
+
+func listTenantConnectionFolderIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	return listPages(func(nextToken string) ([]pipes.Connection, *string, *http.Response, error) {
+		req := client.APIClient.TenantConnectionFolders.List(ctx)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(folder pipes.Connection) bool {
+		return yield(resourceId{"tenant-id": folder.TenantId, "connection-folder-id": folder.Id}, folder.GetTitle())
+	})
+}
+
+func listWorkspaceConnectionFolderIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	scope := workspaceListScope(args)
+	return listPages(func(nextToken string) ([]pipes.Connection, *string, *http.Response, error) {
+		resp, r, err := workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.ListConnectionsResponse, *http.Response, error) {
+				req := client.APIClient.UserWorkspaceConnectionFolders.List(ctx, userHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			},
+			func(orgHandle string) (pipes.ListConnectionsResponse, *http.Response, error) {
+				req := client.APIClient.OrgWorkspaceConnectionFolders.List(ctx, orgHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			})
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(folder pipes.Connection) bool {
+		return yield(scope.resourceId("connection-folder-id", folder.Id), folder.GetTitle())
+	})
+}
+
+func listOrganizationMemberIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	orgHandle := args["organization"]
+	return listPages(func(nextToken string) ([]pipes.OrgUser, *string, *http.Response, error) {
+		req := client.APIClient.OrgMembers.List(ctx, orgHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(member pipes.OrgUser) bool {
+		// Members invited by email have no handle until they accept the invitation, and cannot be imported
+		if member.UserHandle == "" {
+			return true
+		}
+		return yield(resourceId{"org-handle": orgHandle, "user-handle": member.UserHandle}, member.UserHandle)
+	})
+}
+
+func listOrganizationWorkspaceMemberIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	orgHandle, workspaceHandle := args["organization"], args["workspace"]
+	return listPages(func(nextToken string) ([]pipes.OrgWorkspaceUser, *string, *http.Response, error) {
+		req := client.APIClient.OrgWorkspaceMembers.List(ctx, orgHandle, workspaceHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(member pipes.OrgWorkspaceUser) bool {
+		if member.UserHandle == "" {
+			return true
+		}
+		return yield(resourceId{"org-handle": orgHandle, "workspace-handle": workspaceHandle, "user-handle": member.UserHandle}, member.UserHandle)
+	})
+}
+
+func listTenantMemberIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	tenantHandle := args["tenant_handle"]
+	return listPages(func(nextToken string) ([]pipes.TenantUser, *string, *http.Response, error) {
+		req := client.APIClient.TenantMembers.List(ctx, tenantHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(member pipes.TenantUser) bool {
+		name := member.UserId
+		if member.User != nil && member.User.Handle != "" {
+			name = member.User.Handle
+		}
+		return yield(resourceId{"tenant-handle": tenantHandle, "user-id": member.UserId}, name)
+	})
+}
+
+func listTenantNotifierIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	return listPages(func(nextToken string) ([]pipes.Notifier, *string, *http.Response, error) {
+		req := client.APIClient.TenantNotifiers.List(ctx)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(notifier pipes.Notifier) bool {
+		return yield(resourceId{"notifier-name": notifier.Name}, notifier.Name)
+	})
+}
+
+func listOrganizationNotifierIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	orgHandle := args["organization"]
+	return listPages(func(nextToken string) ([]pipes.Notifier, *string, *http.Response, error) {
+		req := client.APIClient.OrgNotifiers.List(ctx, orgHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(notifier pipes.Notifier) bool {
+		return yield(resourceId{"org-handle": orgHandle, "notifier-name": notifier.Name}, notifier.Name)
+	})
+}
+
+func listUserNotifierIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	userHandle := args["user_handle"]
+	if userHandle == "" {
+		actorHandle, r, err := getUserHandler(ctx, client)
+		if err = lookupError("the user of the token", r, err); err != nil {
+			return err
+		}
+		userHandle = actorHandle
+	}
+	return listPages(func(nextToken string) ([]pipes.Notifier, *string, *http.Response, error) {
+		req := client.APIClient.UserNotifiers.List(ctx, userHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(notifier pipes.Notifier) bool {
+		return yield(resourceId{"user-handle": userHandle, "notifier-name": notifier.Name}, notifier.Name)
+	})
+}
+
+func listWorkspaceNotifierIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	scope := workspaceListScope(args)
+	return listPages(func(nextToken string) ([]pipes.Notifier, *string, *http.Response, error) {
+		resp, r, err := workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.ListNotifiersResponse, *http.Response, error) {
+				req := client.APIClient.UserWorkspaceNotifiers.List(ctx, userHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			},
+			func(orgHandle string) (pipes.ListNotifiersResponse, *http.Response, error) {
+				req := client.APIClient.OrgWorkspaceNotifiers.List(ctx, orgHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			})
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(notifier pipes.Notifier) bool {
+		return yield(scope.resourceId("notifier-name", notifier.Name), notifier.Name)
+	})
+}
+
+func listWorkspaceModIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	scope := workspaceListScope(args)
+	return listPages(func(nextToken string) ([]pipes.WorkspaceMod, *string, *http.Response, error) {
+		resp, r, err := workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.ListWorkspaceModsResponse, *http.Response, error) {
+				req := client.APIClient.UserWorkspaceMods.List(ctx, userHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			},
+			func(orgHandle string) (pipes.ListWorkspaceModsResponse, *http.Response, error) {
+				req := client.APIClient.OrgWorkspaceMods.List(ctx, orgHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			})
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(mod pipes.WorkspaceMod) bool {
+		return yield(scope.resourceId("mod-alias", mod.GetAlias()), mod.GetAlias())
+	})
+}
+
+func listWorkspaceFlowpipeTriggerIds(ctx context.Context, client *PipesClient, args map[string]string, yield func(resourceId, string) bool) error {
+	scope := workspaceListScope(args)
+	return listPages(func(nextToken string) ([]pipes.ModTriggerInfo, *string, *http.Response, error) {
+		resp, r, err := workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.ListTriggersResponse, *http.Response, error) {
+				req := client.APIClient.UserWorkspaceFlowpipeTriggers.List(ctx, userHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			},
+			func(orgHandle string) (pipes.ListTriggersResponse, *http.Response, error) {
+				req := client.APIClient.OrgWorkspaceFlowpipeTriggers.List(ctx, orgHandle, scope.workspace)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			})
+		return resp.GetItems(), resp.NextToken, r, err
+	}, func(trigger pipes.ModTriggerInfo) bool {
+		return yield(scope.resourceId("trigger-id", trigger.GetId()), trigger.GetName())
+	})
+}
@@ -0,0 +1,273 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// listResource:: A list resource, used by "terraform query" to find the existing objects of a resource type, e.g. to
+// generate the import blocks of the workspaces of an organization. The objects are identified by the named parts of
+// their ID, which make up the identity of the resource.
+type listResource struct {
+	// The arguments of the list block, all of them strings
+	arguments map[string]*schema.Schema
+	// The format of the ID of the resource
+	idFormat resourceIdFormat
+	// List the objects matching the arguments, calling yield with the ID and the display name of each object until it
+	// returns false
+	list func(ctx context.Context, client *PipesClient, args map[string]string, yield func(id resourceId, name string) bool) error
+}
+
+// listPages:: Call a paged list API until there are no more pages, or the items are no longer wanted
+func listPages[T any](list func(nextToken string) ([]T, *string, *http.Response, error), yield func(T) bool) error {
+	var nextToken string
+	for {
+		items, next, r, err := list(nextToken)
+		if err != nil {
+			if r == nil {
+				return err
+			}
+			return fmt.Errorf("%s", decodeResponse(r))
+		}
+		for _, item := range items {
+			if !yield(item) {
+				return nil
+			}
+		}
+		if next == nil || *next == "" {
+			return nil
+		}
+		nextToken = *next
+	}
+}
+
+// ProviderServer:: The gRPC server of the provider, i.e. the server of the SDK with the list resources, which the
+// SDK does not support yet
+func ProviderServer() tfprotov5.ProviderServer {
+	provider := Provider()
+	return listProviderServer{
+		ProviderServer: schema.NewGRPCProviderServer(provider),
+		provider:       provider,
+		listResources:  listResources(),
+	}
+}
+
+type listProviderServer struct {
+	tfprotov5.ProviderServer
+	provider      *schema.Provider
+	listResources map[string]listResource
+}
+
+func (s listProviderServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.ProviderServer.GetMetadata(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	names := make([]string, 0, len(s.listResources))
+	for name := range s.listResources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resp.ListResources = append(resp.ListResources, tfprotov5.ListResourceMetadata{TypeName: name})
+	}
+	return resp, nil
+}
+
+func (s listProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	if resp.ListResourceSchemas == nil {
+		resp.ListResourceSchemas = map[string]*tfprotov5.Schema{}
+	}
+	for name, lr := range s.listResources {
+		resp.ListResourceSchemas[name] = lr.schema()
+	}
+	return resp, nil
+}
+
+// ValidateListResourceConfig:: The list blocks are validated by Terraform against their schema, and the values of their
+// arguments may not be known yet
+func (s listProviderServer) ValidateListResourceConfig(ctx context.Context, req *tfprotov5.ValidateListResourceConfigRequest) (*tfprotov5.ValidateListResourceConfigResponse, error) {
+	if _, ok := s.listResources[req.TypeName]; !ok {
+		return s.ProviderServer.(tfprotov5.ProviderServerWithListResource).ValidateListResourceConfig(ctx, req)
+	}
+	return &tfprotov5.ValidateListResourceConfigResponse{}, nil
+}
+
+func (s listProviderServer) ListResource(ctx context.Context, req *tfprotov5.ListResourceRequest) (*tfprotov5.ListResourceServerStream, error) {
+	lr, ok := s.listResources[req.TypeName]
+	if !ok {
+		return s.ProviderServer.(tfprotov5.ProviderServerWithListResource).ListResource(ctx, req)
+	}
+	fail := func(err error) (*tfprotov5.ListResourceServerStream, error) {
+		result := tfprotov5.ListResourceResult{Diagnostics: []*tfprotov5.Diagnostic{listDiagnostic(req.TypeName, err)}}
+		return &tfprotov5.ListResourceServerStream{Results: func(yield func(tfprotov5.ListResourceResult) bool) { yield(result) }}, nil
+	}
+
+	args, err := lr.decodeArguments(req.Config)
+	if err != nil {
+		return fail(err)
+	}
+	client, ok := s.provider.Meta().(*PipesClient)
+	if !ok {
+		return fail(fmt.Errorf("the provider is not configured"))
+	}
+	res := s.provider.ResourcesMap[req.TypeName]
+
+	return &tfprotov5.ListResourceServerStream{
+		Results: func(yield func(tfprotov5.ListResourceResult) bool) {
+			var count int64
+			err := lr.list(ctx, client, args, func(id resourceId, name string) bool {
+				result := tfprotov5.ListResourceResult{DisplayName: name}
+				identity, err := lr.identity(id)
+				if err == nil && req.IncludeResource {
+					result.Resource, err = readListedResource(ctx, res, client, lr.idFormat.format(id))
+				}
+				if err != nil {
+					result.Diagnostics = append(result.Diagnostics, listDiagnostic(req.TypeName, err))
+				}
+				result.Identity = identity
+				count++
+				return yield(result) && (req.Limit <= 0 || count < req.Limit)
+			})
+			if err != nil {
+				yield(tfprotov5.ListResourceResult{Diagnostics: []*tfprotov5.Diagnostic{listDiagnostic(req.TypeName, err)}})
+			}
+		},
+	}, nil
+}
+
+// listDiagnostic:: The error diagnostic of a list resource
+func listDiagnostic(typeName string, err error) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  fmt.Sprintf("Error listing %s", typeName),
+		Detail:   err.Error(),
+	}
+}
+
+// schema:: The schema of the list block of a list resource
+func (lr listResource) schema() *tfprotov5.Schema {
+	names := make([]string, 0, len(lr.arguments))
+	for name := range lr.arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	block := &tfprotov5.SchemaBlock{}
+	for _, name := range names {
+		argument := lr.arguments[name]
+		block.Attributes = append(block.Attributes, &tfprotov5.SchemaAttribute{
+			Name:        name,
+			Type:        tftypes.String,
+			Description: argument.Description,
+			Required:    argument.Required,
+			Optional:    !argument.Required,
+		})
+	}
+	return &tfprotov5.Schema{Block: block}
+}
+
+// argumentsType:: The type of the list block of a list resource
+func (lr listResource) argumentsType() tftypes.Object {
+	attributes := map[string]tftypes.Type{}
+	for name := range lr.arguments {
+		attributes[name] = tftypes.String
+	}
+	return tftypes.Object{AttributeTypes: attributes}
+}
+
+// decodeArguments:: The arguments of the list block of a list resource, with an empty value for an argument which is not
+// set. An error is returned for a missing required argument.
+func (lr listResource) decodeArguments(config *tfprotov5.DynamicValue) (map[string]string, error) {
+	args := map[string]string{}
+	values := map[string]tftypes.Value{}
+	if config != nil {
+		value, err := config.Unmarshal(lr.argumentsType())
+		if err != nil {
+			return nil, err
+		}
+		if !value.IsNull() && value.IsKnown() {
+			if err := value.As(&values); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for name, argument := range lr.arguments {
+		var arg *string
+		if value, ok := values[name]; ok && value.IsKnown() {
+			if err := value.As(&arg); err != nil {
+				return nil, err
+			}
+		}
+		if arg != nil {
+			args[name] = *arg
+		}
+		if argument.Required && args[name] == "" {
+			return nil, fmt.Errorf("the argument %q is required", name)
+		}
+	}
+	return args, nil
+}
+
+// identity:: The identity of a listed object, from the named parts of its ID
+func (lr listResource) identity(id resourceId) (*tfprotov5.ResourceIdentityData, error) {
+	attributes := lr.idFormat.identityAttributes()
+	types := map[string]tftypes.Type{}
+	for name := range attributes {
+		types[name] = tftypes.String
+	}
+	identityType := tftypes.Object{AttributeTypes: types}
+
+	values := identityValues(id)
+	identity := map[string]tftypes.Value{}
+	for name := range attributes {
+		if value, ok := values[name]; ok {
+			identity[name] = tftypes.NewValue(tftypes.String, value)
+		} else {
+			identity[name] = tftypes.NewValue(tftypes.String, nil)
+		}
+	}
+	data, err := tfprotov5.NewDynamicValue(identityType, tftypes.NewValue(identityType, identity))
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov5.ResourceIdentityData{IdentityData: &data}, nil
+}
+
+// readListedResource:: The state of a listed object, read with the resource, for "terraform query" to generate its
+// configuration
+func readListedResource(ctx context.Context, res *schema.Resource, client *PipesClient, id string) (*tfprotov5.DynamicValue, error) {
+	d := res.Data(&terraform.InstanceState{ID: id})
+	for _, diagnostic := range res.ReadContext(ctx, d, client) {
+		if diagnostic.Severity == diag.Error {
+			return nil, fmt.Errorf("%s %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+	state := d.State()
+	if state == nil {
+		return nil, fmt.Errorf("%s not found", id)
+	}
+	stateType := res.CoreConfigSchema().ImpliedType()
+	value, err := state.AttrsAsObjectValue(stateType)
+	if err != nil {
+		return nil, err
+	}
+	data, err := msgpack.Marshal(value, stateType)
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov5.DynamicValue{MsgPack: data}, nil
+}
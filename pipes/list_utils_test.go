@@ -0,0 +1,227 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/turbot/go-kit/types"
+)
+
+func TestListProviderServerSchema(t *testing.T) {
+	ctx := context.Background()
+	server := ProviderServer()
+
+	metadata, err := server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+	if err != nil {
+		t.Fatalf("GetMetadata: %v", err)
+	}
+	listed := map[string]bool{}
+	for _, lr := range metadata.ListResources {
+		listed[lr.TypeName] = true
+	}
+
+	providerSchema, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("GetResourceIdentitySchemas: %v", err)
+	}
+
+	for name, lr := range listResources() {
+		if !listed[name] {
+			t.Errorf("%s: missing from the list resources of the metadata", name)
+		}
+		if _, ok := providerSchema.ListResourceSchemas[name]; !ok {
+			t.Errorf("%s: missing list resource schema", name)
+		}
+		if _, ok := providerSchema.ResourceSchemas[name]; !ok {
+			t.Errorf("%s: not a resource of the provider", name)
+		}
+		identitySchema, ok := identitySchemas.IdentitySchemas[name]
+		if !ok {
+			t.Errorf("%s: missing identity schema", name)
+			continue
+		}
+		attributes := lr.idFormat.identityAttributes()
+		if len(identitySchema.IdentityAttributes) != len(attributes) {
+			t.Errorf("%s: expected %d identity attributes, got %d", name, len(attributes), len(identitySchema.IdentityAttributes))
+		}
+		for _, attribute := range identitySchema.IdentityAttributes {
+			if required, ok := attributes[attribute.Name]; !ok || required != attribute.RequiredForImport {
+				t.Errorf("%s: unexpected identity attribute %s", name, attribute.Name)
+			}
+		}
+	}
+}
+
+func TestListResourceIdentity(t *testing.T) {
+	lr := listResources()["pipes_workspace_mod"]
+	identityType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"org_handle":       tftypes.String,
+		"workspace_handle": tftypes.String,
+		"mod_alias":        tftypes.String,
+	}}
+
+	cases := map[string]map[string]*string{
+		"myworkspace/mymod":       {"org_handle": nil, "workspace_handle": types.String("myworkspace"), "mod_alias": types.String("mymod")},
+		"myorg/myworkspace/mymod": {"org_handle": types.String("myorg"), "workspace_handle": types.String("myworkspace"), "mod_alias": types.String("mymod")},
+	}
+	for rawId, expected := range cases {
+		id, err := lr.idFormat.parse(rawId)
+		if err != nil {
+			t.Fatalf("parse(%q): %v", rawId, err)
+		}
+		identity, err := lr.identity(id)
+		if err != nil {
+			t.Fatalf("identity(%q): %v", rawId, err)
+		}
+		value, err := identity.IdentityData.Unmarshal(identityType)
+		if err != nil {
+			t.Fatalf("identity(%q): %v", rawId, err)
+		}
+		var values map[string]tftypes.Value
+		if err := value.As(&values); err != nil {
+			t.Fatalf("identity(%q): %v", rawId, err)
+		}
+		for name, want := range expected {
+			var got *string
+			if err := values[name].As(&got); err != nil {
+				t.Fatalf("identity(%q): %s: %v", rawId, name, err)
+			}
+			if (got == nil) != (want == nil) || (got != nil && *got != *want) {
+				t.Errorf("identity(%q): %s: expected %v, got %v", rawId, name, want, got)
+			}
+		}
+	}
+}
+
+func TestListResourceArguments(t *testing.T) {
+	lr := listResources()["pipes_workspace_mod"]
+	argumentsType := lr.argumentsType()
+
+	config := func(values map[string]tftypes.Value) *tfprotov5.DynamicValue {
+		value, err := tfprotov5.NewDynamicValue(argumentsType, tftypes.NewValue(argumentsType, values))
+		if err != nil {
+			t.Fatalf("NewDynamicValue: %v", err)
+		}
+		return &value
+	}
+
+	args, err := lr.decodeArguments(config(map[string]tftypes.Value{
+		"organization": tftypes.NewValue(tftypes.String, nil),
+		"workspace":    tftypes.NewValue(tftypes.String, "myworkspace"),
+	}))
+	if err != nil {
+		t.Fatalf("decodeArguments: %v", err)
+	}
+	if args["workspace"] != "myworkspace" || args["organization"] != "" {
+		t.Errorf("decodeArguments: unexpected arguments %v", args)
+	}
+
+	_, err = lr.decodeArguments(config(map[string]tftypes.Value{
+		"organization": tftypes.NewValue(tftypes.String, "myorg"),
+		"workspace":    tftypes.NewValue(tftypes.String, nil),
+	}))
+	if err == nil || !strings.Contains(err.Error(), `"workspace" is required`) {
+		t.Errorf("decodeArguments: expected an error for the missing workspace, got %v", err)
+	}
+}
+
+// The workspaces of an organization are listed through the gRPC server of the provider, over two pages
+func TestListProviderServerListResource(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v0/org/myorg/workspace":
+			if r.URL.Query().Get("next_token") == "" {
+				fmt.Fprint(w, `{"items": [{"id": "w_1", "handle": "dev", "version_id": 1}], "next_token": "page2"}`)
+			} else {
+				fmt.Fprint(w, `{"items": [{"id": "w_2", "handle": "prod", "version_id": 1}]}`)
+			}
+		case "/api/v0/org/myorg/workspace/dev":
+			fmt.Fprint(w, `{"id": "w_1", "handle": "dev", "version_id": 1}`)
+		case "/api/v0/org/myorg/workspace/prod":
+			fmt.Fprint(w, `{"id": "w_2", "handle": "prod", "version_id": 1}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := ProviderServer().(listProviderServer)
+	server.provider.SetMeta(client)
+
+	lr := server.listResources["pipes_workspace"]
+	argumentsType := lr.argumentsType()
+	config, err := tfprotov5.NewDynamicValue(argumentsType, tftypes.NewValue(argumentsType, map[string]tftypes.Value{
+		"organization": tftypes.NewValue(tftypes.String, "myorg"),
+	}))
+	if err != nil {
+		t.Fatalf("NewDynamicValue: %v", err)
+	}
+	identityType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"org_handle":       tftypes.String,
+		"workspace_handle": tftypes.String,
+	}}
+	stateType := server.provider.ResourcesMap["pipes_workspace"].CoreConfigSchema().ImpliedType()
+
+	cases := map[string]struct {
+		limit    int64
+		expected []string
+	}{
+		"all pages": {expected: []string{"dev", "prod"}},
+		"limit":     {limit: 1, expected: []string{"dev"}},
+	}
+	for name, c := range cases {
+		stream, err := server.ListResource(ctx, &tfprotov5.ListResourceRequest{
+			TypeName:        "pipes_workspace",
+			Config:          &config,
+			IncludeResource: true,
+			Limit:           c.limit,
+		})
+		if err != nil {
+			t.Fatalf("%s: ListResource: %v", name, err)
+		}
+		var listed []string
+		for result := range stream.Results {
+			if len(result.Diagnostics) > 0 {
+				t.Fatalf("%s: unexpected diagnostics %s: %s", name, result.Diagnostics[0].Summary, result.Diagnostics[0].Detail)
+			}
+			listed = append(listed, result.DisplayName)
+
+			identity, err := result.Identity.IdentityData.Unmarshal(identityType)
+			if err != nil {
+				t.Fatalf("%s: identity: %v", name, err)
+			}
+			var values map[string]tftypes.Value
+			var orgHandle, workspaceHandle string
+			if err := identity.As(&values); err != nil {
+				t.Fatalf("%s: identity: %v", name, err)
+			}
+			values["org_handle"].As(&orgHandle)
+			values["workspace_handle"].As(&workspaceHandle)
+			if orgHandle != "myorg" || workspaceHandle != result.DisplayName {
+				t.Errorf("%s: unexpected identity %s/%s for %s", name, orgHandle, workspaceHandle, result.DisplayName)
+			}
+
+			state, err := msgpack.Unmarshal(result.Resource.MsgPack, stateType)
+			if err != nil {
+				t.Fatalf("%s: resource: %v", name, err)
+			}
+			if handle := state.GetAttr("handle").AsString(); handle != result.DisplayName {
+				t.Errorf("%s: expected the state of %s, got %s", name, result.DisplayName, handle)
+			}
+		}
+		if strings.Join(listed, ",") != strings.Join(c.expected, ",") {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, listed)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package pipes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/turbot/pipes-sdk-go"
+)
+
+var notifyTypes = []string{"email", "slack", "msteams"}
+
+// notifiesSchema:: The raw JSON form of the notify targets of a notifier, kept for backward compatibility
+func notifiesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: notifiesJSONStringsEqual,
+		ExactlyOneOf:     []string{"notifies", "notify", "notifies_wo"},
+	}
+}
+
+// notifySchema:: The structured form of the notify targets of a notifier
+func notifySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeSet,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"notifies", "notify", "notifies_wo"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(notifyTypes, false),
+				},
+				"integration": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^i_[a-z0-9]{20}$`), "Integration must be the unique identifier of an integration, e.g. i_cdi2d6c3ul7ugl1l6e30."),
+				},
+				"channel": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(`^#?[A-Za-z0-9][A-Za-z0-9._-]{0,79}$`), "Channel must be a valid Slack channel name or ID."),
+				},
+				"to": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`), "Must be a valid email address."),
+					},
+				},
+				"cc": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`), "Must be a valid email address."),
+					},
+				},
+			},
+		},
+	}
+}
+
+// notifiesWriteOnlySchema:: The write-only JSON form of the notify targets of a notifier, which is never stored in state
+func notifiesWriteOnlySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		WriteOnly:    true,
+		ValidateFunc: validation.StringIsJSON,
+		ExactlyOneOf: []string{"notifies", "notify", "notifies_wo"},
+		RequiredWith: []string{"notifies_wo_version"},
+	}
+}
+
+// notifiesWriteOnlyVersionSchema:: Changing the version triggers an update of the write-only notify targets
+func notifiesWriteOnlyVersionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"notifies_wo"},
+	}
+}
+
+// notifierCustomizeDiff:: Validate at plan time the fields of each notify block against its type,
+// and mark the other form of the notify targets as unknown when one of them changes
+func notifierCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" {
+		// an imported notifier, or one switched to notifies_wo without a change of version, may still hold its notify
+		// targets in state, in which case it is updated to clear them
+		oldNotifies, _ := d.GetChange("notifies")
+		config := d.GetRawConfig()
+		writeOnly := !config.IsNull() && !config.GetAttr("notifies_wo").IsNull()
+		if d.HasChange("notifies_wo_version") || writeOnly && oldNotifies.(string) != "" {
+			// the notify targets are not kept in state while they are managed by notifies_wo
+			for _, key := range []string{"notifies", "notify"} {
+				if value := d.GetRawConfig().GetAttr(key); !value.IsKnown() || !value.IsNull() && (!value.Type().IsSetType() || value.LengthInt() > 0) {
+					continue
+				}
+				if err := d.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
+		} else if d.HasChange("notify") {
+			if err := d.SetNewComputed("notifies"); err != nil {
+				return err
+			}
+		} else if d.HasChange("notifies") {
+			if err := d.SetNewComputed("notify"); err != nil {
+				return err
+			}
+		}
+	}
+	if !d.NewValueKnown("notify") {
+		return nil
+	}
+	for _, item := range d.Get("notify").(*schema.Set).List() {
+		notify := item.(map[string]interface{})
+		notifyType := notify["type"].(string)
+		channel := notify["channel"].(string)
+		to := notify["to"].(*schema.Set).Len()
+		cc := notify["cc"].(*schema.Set).Len()
+
+		switch notifyType {
+		case "email":
+			if to == 0 {
+				return fmt.Errorf("notify of type email with integration %q: at least one recipient must be set in `to`", notify["integration"])
+			}
+			if channel != "" {
+				return fmt.Errorf("notify of type email with integration %q: `channel` is only supported for notify of type slack", notify["integration"])
+			}
+		case "slack":
+			if channel == "" {
+				return fmt.Errorf("notify of type slack with integration %q: `channel` must be set", notify["integration"])
+			}
+		default:
+			if channel != "" {
+				return fmt.Errorf("notify of type %s with integration %q: `channel` is only supported for notify of type slack", notifyType, notify["integration"])
+			}
+		}
+		if notifyType != "email" && (to > 0 || cc > 0) {
+			return fmt.Errorf("notify of type %s with integration %q: `to` and `cc` are only supported for notify of type email", notifyType, notify["integration"])
+		}
+	}
+	return nil
+}
+
+// getNotifies:: Build the notify targets for the API request from the notifies_wo JSON, the notify blocks or the notifies JSON
+func getNotifies(d *schema.ResourceData) ([]map[string]interface{}, error) {
+	notifies := []map[string]interface{}{}
+
+	if value, ok := d.GetRawConfig().AsValueMap()["notifies_wo"]; ok && !value.IsNull() {
+		if err := json.Unmarshal([]byte(value.AsString()), &notifies); err != nil {
+			return nil, err
+		}
+		return notifies, nil
+	}
+
+	// notify and notifies are both computed, so use the config to find out which form the user has set
+	if notify := d.GetRawConfig().GetAttr("notify"); !notify.IsNull() && notify.LengthInt() > 0 {
+		for _, item := range d.Get("notify").(*schema.Set).List() {
+			notify := item.(map[string]interface{})
+			target := map[string]interface{}{
+				"type":        notify["type"],
+				"integration": notify["integration"],
+			}
+			if channel := notify["channel"].(string); channel != "" {
+				target["channel"] = channel
+			}
+			if to := notify["to"].(*schema.Set); to.Len() > 0 {
+				target["to"] = sortedStrings(to.List())
+			}
+			if cc := notify["cc"].(*schema.Set); cc.Len() > 0 {
+				target["cc"] = sortedStrings(cc.List())
+			}
+			notifies = append(notifies, target)
+		}
+		return notifies, nil
+	}
+
+	if v, ok := d.GetOk("notifies"); ok {
+		if err := json.Unmarshal([]byte(v.(string)), &notifies); err != nil {
+			return nil, err
+		}
+	}
+	return notifies, nil
+}
+
+// setNotifies:: Set the notify targets returned by the API in state, unless they are managed by notifies_wo
+func setNotifies(d *schema.ResourceData, notifies *[]map[string]interface{}) {
+	if notifiesWriteOnly(d) {
+		d.Set("notifies", "")
+		d.Set("notify", []interface{}{})
+		// keep the version in state, even when it is 0, for the next refresh to know the targets are write-only
+		d.Set("notifies_wo_version", d.Get("notifies_wo_version"))
+		return
+	}
+	d.Set("notifies", FormatJson(notifies))
+	d.Set("notify", flattenNotifies(notifies))
+}
+
+// notifiesWriteOnly:: Whether the notify targets are managed by notifies_wo. The configuration is only available on
+// create and update, so on refresh the version of notifies_wo kept in state is used instead.
+func notifiesWriteOnly(d *schema.ResourceData) bool {
+	if config := d.GetRawConfig(); !config.IsNull() {
+		return !config.GetAttr("notifies_wo").IsNull()
+	}
+	state := d.GetRawState()
+	return !state.IsNull() && !state.GetAttr("notifies_wo_version").IsNull()
+}
+
+// flattenNotifies:: Convert the notify targets returned by the API into notify blocks
+func flattenNotifies(notifies *[]map[string]interface{}) []interface{} {
+	result := []interface{}{}
+	if notifies == nil {
+		return result
+	}
+	for _, target := range *notifies {
+		notify := map[string]interface{}{
+			"type":        "",
+			"integration": "",
+			"channel":     "",
+			"to":          []interface{}{},
+			"cc":          []interface{}{},
+		}
+		for _, key := range []string{"type", "integration", "channel"} {
+			if value, ok := target[key].(string); ok {
+				notify[key] = value
+			}
+		}
+		for _, key := range []string{"to", "cc"} {
+			if values, ok := target[key].([]interface{}); ok {
+				notify[key] = values
+			}
+		}
+		result = append(result, notify)
+	}
+	return result
+}
+
+// notifies is a json string
+// apply standard formatting to old and new data, ignoring the order of the targets and of their recipients, then compare
+func notifiesJSONStringsEqual(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+	oldFormatted, err := formatNotifiesJSONString(old)
+	if err != nil {
+		return false
+	}
+	newFormatted, err := formatNotifiesJSONString(new)
+	if err != nil {
+		return false
+	}
+	return oldFormatted == newFormatted
+}
+
+// apply standard formatting to a notifies json string by sorting the recipients of each target, then the targets
+func formatNotifiesJSONString(body string) (string, error) {
+	var notifies []map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &notifies); err != nil {
+		return "", err
+	}
+
+	targets := make([]interface{}, 0, len(notifies))
+	for _, target := range notifies {
+		for key, value := range target {
+			if values, ok := value.([]interface{}); ok {
+				target[key] = sortedJSONValues(values)
+			}
+		}
+		targets = append(targets, target)
+	}
+	return FormatJson(sortedJSONValues(targets)), nil
+}
+
+func sortedStrings(values []interface{}) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.(string))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func sortedJSONValues(values []interface{}) []interface{} {
+	sorted := make([]interface{}, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return FormatJson(sorted[i]) < FormatJson(sorted[j]) })
+	return sorted
+}
+
+// resolveNotifierName:: Replace the notifier of an imported ID given by its ID, e.g. "n_cdi2d6c3ul7ugl1l6e30", by its
+// name, read with the given function
+func resolveNotifierName(id resourceId, get func(notifierId string) (pipes.Notifier, *http.Response, error)) error {
+	notifierId := id["notifier-name"]
+	if !isPipesId(notifierId, "n") {
+		return nil
+	}
+	notifier, r, err := get(notifierId)
+	if err = resolveError(notifierId, r, err); err != nil {
+		return err
+	}
+	if notifier.Name != "" {
+		id["notifier-name"] = notifier.Name
+	}
+	return nil
+}
+
+func resolveTenantNotifierId(ctx context.Context, client *PipesClient, id resourceId) error {
+	return resolveNotifierName(id, func(notifierId string) (pipes.Notifier, *http.Response, error) {
+		return client.APIClient.TenantNotifiers.Get(ctx, notifierId).Execute()
+	})
+}
+
+func resolveOrganizationNotifierId(ctx context.Context, client *PipesClient, id resourceId) error {
+	return resolveNotifierName(id, func(notifierId string) (pipes.Notifier, *http.Response, error) {
+		return client.APIClient.OrgNotifiers.Get(ctx, id["org-handle"], notifierId).Execute()
+	})
+}
+
+func resolveUserNotifierId(ctx context.Context, client *PipesClient, id resourceId) error {
+	return resolveNotifierName(id, func(notifierId string) (pipes.Notifier, *http.Response, error) {
+		return client.APIClient.UserNotifiers.Get(ctx, id["user-handle"], notifierId).Execute()
+	})
+}
+
+func resolveWorkspaceNotifierId(ctx context.Context, client *PipesClient, id resourceId) error {
+	scope := workspaceScopeFromId(id)
+	return resolveNotifierName(id, func(notifierId string) (pipes.Notifier, *http.Response, error) {
+		return workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.Notifier, *http.Response, error) {
+				return client.APIClient.UserWorkspaceNotifiers.Get(ctx, userHandle, scope.workspace, notifierId).Execute()
+			},
+			func(orgHandle string) (pipes.Notifier, *http.Response, error) {
+				return client.APIClient.OrgWorkspaceNotifiers.Get(ctx, orgHandle, scope.workspace, notifierId).Execute()
+			},
+		)
+	})
+}
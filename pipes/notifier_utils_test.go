@@ -0,0 +1,170 @@
+package pipes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestFormatNotifiesJSONString(t *testing.T) {
+	cases := map[string]struct {
+		body     string
+		expected string
+	}{
+		"empty": {
+			body:     `[]`,
+			expected: `[]`,
+		},
+		"single target": {
+			body:     `[{"type": "slack", "integration": "i_cdi2d6c3ul7ugl1l6e30", "channel": "#alerts"}]`,
+			expected: `[{"channel":"#alerts","integration":"i_cdi2d6c3ul7ugl1l6e30","type":"slack"}]`,
+		},
+		"sorted targets and recipients": {
+			body:     `[{"type": "slack", "integration": "i_cdi2d6c3ul7ugl1l6e30", "channel": "#alerts"}, {"type": "email", "integration": "i_cdi2d6c3ul7ugl1l6e31", "to": ["b@example.com", "a@example.com"]}]`,
+			expected: `[{"channel":"#alerts","integration":"i_cdi2d6c3ul7ugl1l6e30","type":"slack"},{"integration":"i_cdi2d6c3ul7ugl1l6e31","to":["a@example.com","b@example.com"],"type":"email"}]`,
+		},
+	}
+	for name, c := range cases {
+		got, err := formatNotifiesJSONString(c.body)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got != c.expected {
+			t.Errorf("%s: expected %s, got %s", name, c.expected, got)
+		}
+	}
+
+	if _, err := formatNotifiesJSONString(`{"type": "slack"}`); err == nil {
+		t.Errorf("expected an error for notifies which are not a JSON array")
+	}
+}
+
+func TestNotifiesJSONStringsEqual(t *testing.T) {
+	old := `[{"type":"email","integration":"i_cdi2d6c3ul7ugl1l6e31","to":["a@example.com","b@example.com"]},{"type":"slack","integration":"i_cdi2d6c3ul7ugl1l6e30","channel":"#alerts"}]`
+	reordered := `[{"channel": "#alerts", "integration": "i_cdi2d6c3ul7ugl1l6e30", "type": "slack"}, {"to": ["b@example.com", "a@example.com"], "integration": "i_cdi2d6c3ul7ugl1l6e31", "type": "email"}]`
+	changed := `[{"type":"slack","integration":"i_cdi2d6c3ul7ugl1l6e30","channel":"#ops"}]`
+
+	if !notifiesJSONStringsEqual("notifies", old, reordered, nil) {
+		t.Errorf("expected reordered notifies to be equal")
+	}
+	if notifiesJSONStringsEqual("notifies", old, changed, nil) {
+		t.Errorf("expected changed notifies to differ")
+	}
+	if notifiesJSONStringsEqual("notifies", "", changed, nil) {
+		t.Errorf("expected unset notifies to differ")
+	}
+}
+
+func TestNotifierValidation(t *testing.T) {
+	cases := map[string]struct {
+		notify   map[string]interface{}
+		expected string
+	}{
+		"email": {
+			notify: map[string]interface{}{"type": "email", "integration": "i_cdi2d6c3ul7ugl1l6e30", "to": []interface{}{"a@example.com"}},
+		},
+		"slack": {
+			notify: map[string]interface{}{"type": "slack", "integration": "i_cdi2d6c3ul7ugl1l6e30", "channel": "#alerts"},
+		},
+		"msteams": {
+			notify: map[string]interface{}{"type": "msteams", "integration": "i_cdi2d6c3ul7ugl1l6e30"},
+		},
+		"integration handle": {
+			notify:   map[string]interface{}{"type": "msteams", "integration": "teams"},
+			expected: "unique identifier of an integration",
+		},
+		"integration typo": {
+			notify:   map[string]interface{}{"type": "msteams", "integration": "i_cdi2d6c3ul7ugl1l6e3"},
+			expected: "unique identifier of an integration",
+		},
+		"email without recipient": {
+			notify:   map[string]interface{}{"type": "email", "integration": "i_cdi2d6c3ul7ugl1l6e30"},
+			expected: "at least one recipient",
+		},
+		"email with channel": {
+			notify:   map[string]interface{}{"type": "email", "integration": "i_cdi2d6c3ul7ugl1l6e30", "to": []interface{}{"a@example.com"}, "channel": "#alerts"},
+			expected: "`channel` is only supported",
+		},
+		"slack without channel": {
+			notify:   map[string]interface{}{"type": "slack", "integration": "i_cdi2d6c3ul7ugl1l6e30"},
+			expected: "`channel` must be set",
+		},
+		"slack with recipient": {
+			notify:   map[string]interface{}{"type": "slack", "integration": "i_cdi2d6c3ul7ugl1l6e30", "channel": "#alerts", "to": []interface{}{"a@example.com"}},
+			expected: "`to` and `cc` are only supported",
+		},
+		"msteams with channel": {
+			notify:   map[string]interface{}{"type": "msteams", "integration": "i_cdi2d6c3ul7ugl1l6e30", "channel": "#alerts"},
+			expected: "`channel` is only supported",
+		},
+		"msteams with cc": {
+			notify:   map[string]interface{}{"type": "msteams", "integration": "i_cdi2d6c3ul7ugl1l6e30", "cc": []interface{}{"a@example.com"}},
+			expected: "`to` and `cc` are only supported",
+		},
+	}
+
+	r := resourceTenantNotifier()
+	for name, c := range cases {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":   "notifier",
+			"state":  "enabled",
+			"notify": []interface{}{c.notify},
+		})
+		var errs []string
+		for _, d := range r.Validate(config) {
+			errs = append(errs, d.Summary+" "+d.Detail)
+		}
+		if len(errs) == 0 {
+			if _, err := r.Diff(context.Background(), nil, config, nil); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+
+		switch {
+		case c.expected == "" && len(errs) > 0:
+			t.Errorf("%s: unexpected errors %v", name, errs)
+		case c.expected != "" && !strings.Contains(strings.Join(errs, "\n"), c.expected):
+			t.Errorf("%s: expected an error containing %q, got %v", name, c.expected, errs)
+		}
+	}
+}
+
+func TestNotifiesWriteOnly(t *testing.T) {
+	r := resourceTenantNotifier()
+	objectType := r.CoreConfigSchema().ImpliedType()
+	value := func(attributes map[string]string) cty.Value {
+		v, err := (&terraform.InstanceState{Attributes: attributes}).AttrsAsObjectValue(objectType)
+		if err != nil {
+			t.Fatalf("AttrsAsObjectValue: %v", err)
+		}
+		return v
+	}
+
+	cases := map[string]struct {
+		config   map[string]string
+		state    map[string]string
+		expected bool
+	}{
+		"configured notifies":                 {config: map[string]string{"notifies": "[]"}, state: map[string]string{"notifies_wo_version": "1"}, expected: false},
+		"configured notifies_wo":              {config: map[string]string{"notifies_wo": "[]", "notifies_wo_version": "0"}, expected: true},
+		"configured notifies_wo on import":    {config: map[string]string{"notifies_wo": "[]", "notifies_wo_version": "1"}, state: map[string]string{"notifies": "[]"}, expected: true},
+		"refresh of notifies":                 {state: map[string]string{"notifies": "[]"}, expected: false},
+		"refresh of notifies_wo":              {state: map[string]string{"notifies_wo_version": "1"}, expected: true},
+		"refresh of notifies_wo of version 0": {state: map[string]string{"notifies_wo_version": "0"}, expected: true},
+	}
+	for name, c := range cases {
+		state := &terraform.InstanceState{ID: "n_cdi2d6c3ul7ugl1l6e30"}
+		if c.config != nil {
+			state.RawConfig = value(c.config)
+		}
+		if c.state != nil {
+			state.RawState = value(c.state)
+		}
+		if got := notifiesWriteOnly(r.Data(state)); got != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, got)
+		}
+	}
+}
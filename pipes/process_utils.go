@@ -0,0 +1,194 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+// The states after which a process will not change any further
+var processTerminalStates = map[pipes.ProcessState]bool{
+	pipes.ProcessCompleted:  true,
+	pipes.ProcessFailed:     true,
+	pipes.ProcessCanceled:   true,
+	pipes.ProcessTerminated: true,
+}
+
+// processRunSchema:: The fields shared by the resources which start a process on create and optionally wait for it to finish
+func processRunSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"triggers": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"wait_for_completion": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"process_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"process_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"state": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"state_reason": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"created_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"updated_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+// getWorkspaceProcess:: Get a process of a user or organization workspace
+func getWorkspaceProcess(ctx context.Context, client *PipesClient, scope workspaceScope, processId string) (pipes.SpProcess, *http.Response, error) {
+	return workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.SpProcess, *http.Response, error) {
+			return client.APIClient.UserWorkspaceProcesses.Get(ctx, userHandle, scope.workspace, processId).Execute()
+		},
+		func(orgHandle string) (pipes.SpProcess, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceProcesses.Get(ctx, orgHandle, scope.workspace, processId).Execute()
+		},
+	)
+}
+
+// waitForWorkspaceProcess:: Poll a workspace process until it reaches a terminal state or the timeout expires
+func waitForWorkspaceProcess(ctx context.Context, client *PipesClient, scope workspaceScope, processId string, timeout time.Duration) (pipes.SpProcess, error) {
+	var process pipes.SpProcess
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		var r *http.Response
+		var err error
+		process, r, err = getWorkspaceProcess(ctx, client, scope, processId)
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("%v", decodeResponse(r)))
+		}
+		if process.State == nil || !processTerminalStates[*process.State] {
+			log.Printf("\n[DEBUG] Waiting for process: %s in workspace: %s, current state: %v", processId, scope.workspace, process.State)
+			return resource.RetryableError(fmt.Errorf("process %s is still %v", processId, process.State))
+		}
+		return nil
+	})
+	return process, err
+}
+
+// setProcessRunProperties:: Set the state of the process started by the resource
+func setProcessRunProperties(d *schema.ResourceData, process pipes.SpProcess) {
+	d.Set("process_id", process.Id)
+	d.Set("process_type", process.Type)
+	d.Set("state", process.State)
+	d.Set("state_reason", process.StateReason)
+	d.Set("created_at", process.CreatedAt)
+	d.Set("updated_at", process.UpdatedAt)
+}
+
+// processRunError:: Report a process which finished without completing, so that the resource is tainted and run again on the next apply
+func processRunError(process pipes.SpProcess) error {
+	if process.State == nil || !processTerminalStates[*process.State] || *process.State == pipes.ProcessCompleted {
+		return nil
+	}
+	if process.StateReason != nil && *process.StateReason != "" {
+		return fmt.Errorf("process %s finished in state %s: %s", process.Id, *process.State, *process.StateReason)
+	}
+	return fmt.Errorf("process %s finished in state %s", process.Id, *process.State)
+}
+
+// startWorkspaceProcessRun:: Record the process started by a run resource in state, waiting for it to finish if requested
+func startWorkspaceProcessRun(ctx context.Context, d *schema.ResourceData, client *PipesClient, scope workspaceScope, processId string) diag.Diagnostics {
+	// If a process is started for a workspace inside an organization the id will be of the
+	// format "orgHandle/workspaceHandle/processId" otherwise "workspaceHandle/processId"
+	d.SetId(scope.id(processId))
+	d.Set("organization", scope.organization)
+	d.Set("process_id", processId)
+
+	var process pipes.SpProcess
+	var r *http.Response
+	var err error
+	if d.Get("wait_for_completion").(bool) {
+		process, err = waitForWorkspaceProcess(ctx, client, scope, processId, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.Errorf("error waiting for process %s: %v", processId, err)
+		}
+	} else {
+		process, r, err = getWorkspaceProcess(ctx, client, scope, processId)
+		if err != nil {
+			return diag.Errorf("error reading process %s: %v", processId, decodeResponse(r))
+		}
+	}
+	setProcessRunProperties(d, process)
+
+	if err = processRunError(process); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// workspaceProcessIdFormat:: The format of the ID of a run resource, i.e. of the process it started
+var workspaceProcessIdFormat = workspaceResourceIdFormat("<process-id>")
+
+// resourceWorkspaceProcessRunRead:: Refresh the state of the process started by a run resource
+func resourceWorkspaceProcessRunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// If a process is started for a workspace inside an organization the id will be of the
+	// format "orgHandle/workspaceHandle/processId" otherwise "workspaceHandle/processId"
+	id, err := workspaceProcessIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	scope := workspaceScopeFromId(id)
+	processId := id["process-id"]
+
+	client := meta.(*PipesClient)
+	process, r, err := getWorkspaceProcess(ctx, client, scope, processId)
+	if err != nil {
+		// A run resource is the record of a process which has already run, so a process purged by Turbot Pipes
+		// keeps its last known state rather than being removed from state and run again on the next apply.
+		// It is only run again when the triggers change.
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			log.Printf("\n[WARN] Process (%s) not found, keeping its last known state", processId)
+			d.Set("organization", scope.organization)
+			d.Set("workspace", scope.workspace)
+			d.Set("process_id", processId)
+			return nil
+		}
+		return diag.Errorf("error reading process %s: %v", processId, decodeResponse(r))
+	}
+
+	d.Set("organization", scope.organization)
+	d.Set("workspace", scope.workspace)
+	setProcessRunProperties(d, process)
+
+	return nil
+}
+
+// resourceWorkspaceProcessRunUpdate:: Only wait_for_completion can change without starting a new process, and it has no effect once the process has started
+func resourceWorkspaceProcessRunUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceWorkspaceProcessRunRead(ctx, d, meta)
+}
+
+// resourceWorkspaceProcessRunDelete:: A started process cannot be undone, so it is only removed from state
+func resourceWorkspaceProcessRunDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
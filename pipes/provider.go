@@ -17,7 +17,7 @@ import (
 
 // Provider configuration for the Turbot Pipes Terraform provider
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:        schema.TypeString,
@@ -42,9 +42,12 @@ func Provider() *schema.Provider {
 			"pipes_organization_integration":                  resourceOrganizationIntegration(),
 			"pipes_organization":                              resourceOrganization(),
 			"pipes_organization_member":                       resourceOrganizationMember(),
+			"pipes_organization_members":                      resourceOrganizationMembers(),
 			"pipes_organization_notifier":                     resourceOrganizationNotifier(),
 			"pipes_organization_workspace_member":             resourceOrganizationWorkspaceMember(),
+			"pipes_organization_workspace_members":            resourceOrganizationWorkspaceMembers(),
 			"pipes_organization_service_account":              resourceOrganizationServiceAccount(),
+			"pipes_organization_settings":                     resourceOrganizationSettings(),
 			"pipes_tenant_connection":                         resourceTenantConnection(),
 			"pipes_tenant_connection_permission":              resourceTenantConnectionPermission(),
 			"pipes_tenant_connection_folder":                  resourceTenantConnectionFolder(),
@@ -52,6 +55,8 @@ func Provider() *schema.Provider {
 			"pipes_tenant_notifier":                           resourceTenantNotifier(),
 			"pipes_tenant_integration":                        resourceTenantIntegration(),
 			"pipes_tenant_member":                             resourceTenantMember(),
+			"pipes_tenant_members":                            resourceTenantMembers(),
+			"pipes_tenant_saml_provider":                      resourceTenantSamlProvider(),
 			"pipes_tenant_service_account":                    resourceTenantServiceAccount(),
 			"pipes_tenant_settings":                           resourceTenantSettings(),
 			"pipes_user_integration":                          resourceUserIntegration(),
@@ -60,33 +65,58 @@ func Provider() *schema.Provider {
 			"pipes_workspace":                                 resourceWorkspace(),
 			"pipes_workspace_aggregator":                      resourceWorkspaceAggregator(),
 			"pipes_workspace_connection":                      resourceWorkspaceConnection(),
+			"pipes_workspace_connection_aws":                  resourceWorkspaceConnectionPlugin("aws"),
+			"pipes_workspace_connection_azure":                resourceWorkspaceConnectionPlugin("azure"),
+			"pipes_workspace_connection_gcp":                  resourceWorkspaceConnectionPlugin("gcp"),
+			"pipes_workspace_connection_github":               resourceWorkspaceConnectionPlugin("github"),
+			"pipes_workspace_connection_kubernetes":           resourceWorkspaceConnectionPlugin("kubernetes"),
 			"pipes_workspace_connection_folder":               resourceWorkspaceConnectionFolder(),
 			"pipes_workspace_datatank":                        resourceWorkspaceDatatank(),
 			"pipes_workspace_datatank_table":                  resourceWorkspaceDatatankTable(),
+			"pipes_workspace_datatank_table_refresh":          resourceWorkspaceDatatankTableRefresh(),
 			"pipes_workspace_flowpipe_mod":                    resourceWorkspaceFlowpipeMod(),
 			"pipes_workspace_flowpipe_mod_variable":           resourceWorkspaceFlowpipeModVariable(),
+			"pipes_workspace_flowpipe_pipeline_run":           resourceWorkspaceFlowpipePipelineRun(),
 			"pipes_workspace_flowpipe_trigger":                resourceWorkspaceFlowpipeTrigger(),
 			"pipes_workspace_mod":                             resourceWorkspaceMod(),
 			"pipes_workspace_mod_variable":                    resourceWorkspaceModVariable(),
 			"pipes_workspace_notifier":                        resourceWorkspaceNotifier(),
 			"pipes_workspace_pipeline":                        resourceWorkspacePipeline(),
+			"pipes_workspace_pipeline_run":                    resourceWorkspacePipelineRun(),
 			"pipes_workspace_schema":                          resourceWorkspaceSchema(),
+			"pipes_workspace_schemas":                         resourceWorkspaceSchemas(),
+			"pipes_workspace_settings":                        resourceWorkspaceSettings(),
 			"pipes_workspace_snapshot":                        resourceWorkspaceSnapshot(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"pipes_tenant_integration":          dataSourceTenantIntegration(),
-			"pipes_organization_integration":    dataSourceOrganizationIntegration(),
-			"pipes_user_integration":            dataSourceUserIntegration(),
-			"pipes_organization":                dataSourceOrganization(),
-			"pipes_process":                     dataSourceProcess(),
-			"pipes_tenant":                      dataSourceTenant(),
-			"pipes_user":                        dataSourceUser(),
-			"pipes_workspace":                   dataSourceWorkspace(),
-			"pipes_workspace_flowpipe_pipeline": dataSourceWorkspaceFlowpipePipeline(),
+			"pipes_audit_logs":                   dataSourceAuditLogs(),
+			"pipes_connection_folders":           dataSourceConnectionFolders(),
+			"pipes_tenant_integration":           dataSourceTenantIntegration(),
+			"pipes_organization_integration":     dataSourceOrganizationIntegration(),
+			"pipes_user_integration":             dataSourceUserIntegration(),
+			"pipes_organization":                 dataSourceOrganization(),
+			"pipes_organization_settings":        dataSourceOrganizationSettings(),
+			"pipes_process":                      dataSourceProcess(),
+			"pipes_tenant":                       dataSourceTenant(),
+			"pipes_user":                         dataSourceUser(),
+			"pipes_workspace":                    dataSourceWorkspace(),
+			"pipes_workspace_datatank":           dataSourceWorkspaceDatatank(),
+			"pipes_workspace_datatanks":          dataSourceWorkspaceDatatanks(),
+			"pipes_workspace_datatank_table":     dataSourceWorkspaceDatatankTable(),
+			"pipes_workspace_datatank_tables":    dataSourceWorkspaceDatatankTables(),
+			"pipes_workspace_flowpipe_pipeline":  dataSourceWorkspaceFlowpipePipeline(),
+			"pipes_workspace_flowpipe_pipelines": dataSourceWorkspaceFlowpipePipelines(),
+			"pipes_workspace_flowpipe_triggers":  dataSourceWorkspaceFlowpipeTriggers(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	// The resources which can be listed are identified by the parts of their ID
+	for name, lr := range listResources() {
+		provider.ResourcesMap[name] = withIdentity(provider.ResourcesMap[name], lr.idFormat)
+	}
+	return provider
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
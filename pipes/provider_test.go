@@ -1,10 +1,13 @@
 package pipes
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/turbot/pipes-sdk-go"
 )
 
 var testAccProviders map[string]*schema.Provider
@@ -36,3 +39,13 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("`PIPES_TOKEN` or `STEAMPIPE_CLOUD_TOKEN` must be set for acceptance tests.")
 	}
 }
+
+// newTestClient:: A client of a stubbed Turbot Pipes API, served by the given handler for the duration of the test
+func newTestClient(t *testing.T, handler http.HandlerFunc) *PipesClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	configuration := pipes.NewConfiguration()
+	configuration.Servers = []pipes.ServerConfiguration{{URL: server.URL + "/api/v0"}}
+	return &PipesClient{APIClient: pipes.NewAPIClient(configuration), Config: &Config{Host: server.URL}}
+}
@@ -2,31 +2,29 @@ package pipes
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
 	"regexp"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-
-	"github.com/turbot/go-kit/types"
-	"github.com/turbot/pipes-sdk-go"
 )
 
 func resourceConnection() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceConnectionCreate,
-		ReadContext:   resourceConnectionRead,
-		UpdateContext: resourceConnectionUpdate,
-		DeleteContext: resourceConnectionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
+	return connectionResource(connectionScope{
+		name:           "resourceConnection",
+		config:         connectionJSONConfig,
+		removeNotFound: true,
+		// Organization is manadatory now since we no longer have user level connections
+		key:          organizationConnectionKey,
+		parseId:      parseOrganizationConnectionId,
+		formatId:     formatOrganizationConnectionId,
+		importFormat: organizationConnectionImportIdFormat,
+		resolveId:    resolveOrganizationConnectionId,
+		create:       organizationConnectionCreate,
+		get:          organizationConnectionGet,
+		update:       organizationConnectionUpdate,
+		delete:       organizationConnectionDelete,
+		attributes: map[string]*schema.Schema{
 			"connection_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -141,317 +139,7 @@ func resourceConnection() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
-}
-
-func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var plugin, connHandle, configString, orgHandle string
-	var config map[string]interface{}
-	var err error
-	var writeConfig bool
-
-	// Organization is manadatory now since we no longer have user level connections
-	if val, ok := d.GetOk("organization"); ok {
-		orgHandle = val.(string)
-	}
-
-	if value, ok := d.GetOk("handle"); ok {
-		connHandle = value.(string)
-	}
-	if value, ok := d.GetOk("plugin"); ok {
-		plugin = value.(string)
-	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
-
-	req := pipes.CreateConnectionRequest{
-		Handle: connHandle,
-		Plugin: plugin,
-	}
-
-	if config != nil {
-		req.SetConfig(config)
-	}
-
-	client := meta.(*PipesClient)
-	var resp pipes.Connection
-	var r *http.Response
-
-	resp, r, err = client.APIClient.OrgConnections.Create(ctx, orgHandle).Request(req).Execute()
-	if err != nil {
-		return diag.Errorf("resourceConnectionCreate. Create connection api error  %v", decodeResponse(r))
-	}
-
-	d.Set("connection_id", resp.Id)
-	d.Set("identity_id", resp.IdentityId)
-	d.Set("organization", orgHandle)
-	d.Set("type", resp.Type)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	// Set config from API response if present
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err == nil && writeConfig && configString != "" && configString != "null" {
-			d.Set("config", configString)
-		}
-	}
-	// format "OrganizationHandle/ConnectionHandle"
-	d.SetId(fmt.Sprintf("%s/%s", orgHandle, *resp.Handle))
-
-	return diags
-}
-
-func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	// Warning or errors can be collected in a slice type
-	var connectionHandle, orgHandle string
-	var diags diag.Diagnostics
-	var err error
-	var r *http.Response
-	var resp pipes.Connection
-	id := d.Id()
-
-	// format "OrganizationHandle/ConnectionHandle"
-	ids := strings.Split(id, "/")
-	orgHandle = ids[0]
-	connectionHandle = ids[1]
-
-	if orgHandle == "" {
-		return diag.Errorf("resourceConnectionRead. Organization handle not present.")
-	}
-	if connectionHandle == "" {
-		return diag.Errorf("resourceConnectionRead. Connection handle not present.")
-	}
-
-	resp, r, err = client.APIClient.OrgConnections.Get(context.Background(), orgHandle, connectionHandle).Execute()
-	if err != nil {
-		if r.StatusCode == 404 {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("Connection (%s) not found", connectionHandle),
-			})
-			d.SetId("")
-			return diags
-		}
-		return diag.Errorf("resourceConnectionRead. Get connection error: %v", decodeResponse(r))
-	}
-
-	// Convert config to string
-	var configString string
-	configString, err = mapToJSONString(resp.GetConfig())
-	if err != nil {
-		return diag.Errorf("resourceOrganizationConnectionRead. Error converting config to string: %v", err)
-	}
-
-	_, writeConfig := d.GetOk("config")
-
-	// assign results back into ResourceData
-	d.Set("connection_id", resp.Id)
-	d.Set("identity_id", resp.IdentityId)
-	d.Set("organization", orgHandle)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("handle", resp.Handle)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	// format "OrganizationHandle/ConnectionHandle"
-	d.SetId(fmt.Sprintf("%s/%s", orgHandle, *resp.Handle))
-
-	return diags
-}
-
-func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	var orgHandle, configString string
-	var r *http.Response
-	var resp pipes.Connection
-	var err error
-	var config map[string]interface{}
-	var writeConfig bool
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
-	// Organization is manadatory now since we no longer have user level connections
-	if val, ok := d.GetOk("organization"); ok {
-		orgHandle = val.(string)
-	}
-
-	oldConnectionHandle, newConnectionHandle := d.GetChange("handle")
-	if newConnectionHandle.(string) == "" {
-		return diag.Errorf("handle must be configured")
-	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
-
-	req := pipes.UpdateConnectionRequest{Handle: types.String(newConnectionHandle.(string))}
-	if config != nil {
-		req.SetConfig(config)
-	}
-
-	resp, r, err = client.APIClient.OrgConnections.Update(context.Background(), orgHandle, oldConnectionHandle.(string)).Request(req).Execute()
-	if err != nil {
-		return diag.Errorf("resourceConnectionUpdate. Update connection error: %v", decodeResponse(r))
-	}
-
-	d.Set("handle", resp.Handle)
-	d.Set("organization", orgHandle)
-	d.Set("connection_id", resp.Id)
-	d.Set("identity_id", resp.IdentityId)
-	d.Set("type", resp.Type)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	d.Set("plugin", *resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	// Set config from API response if present
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err == nil && writeConfig && configString != "" && configString != "null" {
-			d.Set("config", configString)
-		}
-	}
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	// format "OrganizationHandle/ConnectionHandle"
-	d.SetId(fmt.Sprintf("%s/%s", orgHandle, *resp.Handle))
-
-	return diags
-}
-
-func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var connectionHandle, orgHandle string
-
-	// Organization is manadatory now since we no longer have user level connections
-	if val, ok := d.GetOk("organization"); ok {
-		orgHandle = val.(string)
-	}
-	if value, ok := d.GetOk("handle"); ok {
-		connectionHandle = value.(string)
-	}
-
-	var err error
-	var r *http.Response
-
-	_, r, err = client.APIClient.OrgConnections.Delete(ctx, orgHandle, connectionHandle).Execute()
-	if err != nil {
-		return diag.Errorf("resourceConnectionDelete. Delete connection error:	%v", decodeResponse(r))
-	}
-
-	// clear the id to show we have deleted
-	d.SetId("")
-
-	return diags
+	})
 }
 
 // config is a json string
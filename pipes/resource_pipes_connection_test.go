@@ -225,6 +225,31 @@ func testAccCheckConnectionExists(n string) resource.TestCheckFunc {
 	}
 }
 
+// testAccImportStateIdFromAttribute:: Import a resource by the value of one of its attributes, e.g. its ID instead of its
+// handle, expecting the canonical ID of the resource once imported
+func testAccImportStateIdFromAttribute(name, attribute string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("Not found: %s in %s", name, s.RootModule().Path)
+		}
+		return rs.Primary.Attributes[attribute], nil
+	}
+}
+
+// testAccCheckImportedId:: Check the ID of an imported resource was normalized to an ID ending with the given handles
+func testAccCheckImportedId(expected string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+		}
+		if id := states[0].ID; id != expected && !strings.HasSuffix(id, "/"+expected) {
+			return fmt.Errorf("expected imported ID ending with %q, got %q", expected, id)
+		}
+		return nil
+	}
+}
+
 func testCheckJSONString(name, key, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -261,3 +286,18 @@ func testCheckJSONString(name, key, value string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+// testAccCheckImportedFolderId:: Check the ID of a connection folder imported by its ID alone was normalized to an ID
+// ending with the ID of the folder
+func testAccCheckImportedFolderId() resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		if len(states) != 1 {
+			return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+		}
+		folderId := states[0].Attributes["connection_folder_id"]
+		if folderId == "" || !strings.HasSuffix(states[0].ID, "/"+folderId) {
+			return fmt.Errorf("expected imported ID ending with the folder ID %q, got %q", folderId, states[0].ID)
+		}
+		return nil
+	}
+}
@@ -12,15 +12,15 @@ import (
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var organizationIdFormat = newResourceIdFormat("<org-handle>")
+
 func resourceOrganization() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationCreate,
 		ReadContext:   resourceOrganizationRead,
 		UpdateContext: resourceOrganizationUpdate,
 		DeleteContext: resourceOrganizationDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(organizationIdFormat),
 		Schema: map[string]*schema.Schema{
 			"handle": {
 				Type:         schema.TypeString,
@@ -122,7 +122,11 @@ func resourceOrganizationRead(ctx context.Context, d *schema.ResourceData, meta
 	var diags diag.Diagnostics
 
 	client := meta.(*PipesClient)
-	handle := d.Id()
+	id, err := organizationIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	handle := id["org-handle"]
 
 	resp, r, err := client.APIClient.Orgs.Get(context.Background(), handle).Execute()
 	if err != nil {
@@ -204,7 +208,11 @@ func resourceOrganizationUpdate(ctx context.Context, d *schema.ResourceData, met
 
 func resourceOrganizationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*PipesClient)
-	handle := d.Id()
+	id, err := organizationIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	handle := id["org-handle"]
 
 	_, r, err := client.APIClient.Orgs.Delete(ctx, handle).Execute()
 	if err != nil {
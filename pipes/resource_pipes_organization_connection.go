@@ -5,26 +5,33 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/turbot/go-kit/types"
 	"github.com/turbot/pipes-sdk-go"
 )
 
 func resourceOrganizationConnection() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceOrganizationConnectionCreate,
-		ReadContext:   resourceOrganizationConnectionRead,
-		UpdateContext: resourceOrganizationConnectionUpdate,
-		DeleteContext: resourceOrganizationConnectionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+	return connectionResource(connectionScope{
+		name: "resourceOrganizationConnection",
+		renames: map[string]string{
+			"identity_id":                    "organization_id",
+			"last_update_attempt_at":         "last_update_attempted_at",
+			"last_update_attempt_process_id": "last_update_attempted_at_process_id",
 		},
-		Schema: map[string]*schema.Schema{
+		config:         connectionJSONConfig,
+		removeNotFound: true,
+		key:            organizationConnectionKey,
+		parseId:        parseOrganizationConnectionId,
+		formatId:       formatOrganizationConnectionId,
+		importFormat:   organizationConnectionImportIdFormat,
+		resolveId:      resolveOrganizationConnectionId,
+		create:         organizationConnectionCreate,
+		get:            organizationConnectionGet,
+		update:         organizationConnectionUpdate,
+		delete:         organizationConnectionDelete,
+		attributes: map[string]*schema.Schema{
 			"connection_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -189,355 +196,74 @@ func resourceOrganizationConnection() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
-func resourceOrganizationConnectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var orgHandle, plugin, connHandle, configString, parentId string
-	var config map[string]interface{}
-	var err error
-	var writeConfig bool
-
-	// Get details about the organization where the connection would be created
-	if val, ok := d.GetOk("organization"); ok {
-		orgHandle = val.(string)
-	}
-
-	// Get general information about the connection to be created
-	if value, ok := d.GetOk("handle"); ok {
-		connHandle = value.(string)
-	}
-	if value, ok := d.GetOk("plugin"); ok {
-		plugin = value.(string)
-	}
-	if value, ok := d.GetOk("parent_id"); ok {
-		parentId = value.(string)
+// The connection is created at an organization level
+// The id would be of format "OrganizationHandle/ConnectionHandle"
+func organizationConnectionKey(d *schema.ResourceData) connectionKey {
+	return connectionKey{
+		organization: d.Get("organization").(string),
+		handle:       d.Get("handle").(string),
 	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
-
-	req := pipes.CreateConnectionRequest{
-		Handle: connHandle,
-		Plugin: plugin,
-	}
-
-	// Pass the parent_id if its set
-	if parentId != "" {
-		req.SetParentId(parentId)
-	}
-
-	// Pass the config if its set
-	if config != nil {
-		req.SetConfig(config)
-	}
-
-	client := meta.(*PipesClient)
-	var resp pipes.Connection
-	var r *http.Response
-
-	resp, r, err = client.APIClient.OrgConnections.Create(ctx, orgHandle).Request(req).Execute()
-	if err != nil {
-		return diag.Errorf("resourceOrganizationConnectionCreate. Create connection api error  %v", decodeResponse(r))
-	}
-
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceOrganizationConnectionCreate. Error converting config to string: %v", err)
-		}
-	}
-
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("organization_id", resp.IdentityId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	// computed connection state fields
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempted_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempted_at_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	d.Set("organization", orgHandle)
-	// The connection is being created at an organization level
-	// The id would be of format "OrganizationHandle/ConnectionHandle"
-	d.SetId(fmt.Sprintf("%s/%s", orgHandle, *resp.Handle))
-
-	return diags
 }
 
-func resourceOrganizationConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	// Warning or errors can be collected in a slice type
-	var connectionHandle, orgId, configString string
-	var diags diag.Diagnostics
-
-	// Its an org level connection so the id would be of format "OrganizationHandle/ConnectionHandle"
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 2 {
-		orgId = ids[0]
-		connectionHandle = ids[1]
-	}
+var organizationConnectionIdFormat = newResourceIdFormat("<org-handle>/<connection-handle>")
 
-	if orgId == "" {
-		return diag.Errorf("resourceOrganizationConnectionRead. Organization information not present.")
-	}
-	if connectionHandle == "" {
-		return diag.Errorf("resourceOrganizationConnectionRead. Connection handle not present.")
-	}
-
-	resp, r, err := client.APIClient.OrgConnections.Get(context.Background(), orgId, connectionHandle).Execute()
+func parseOrganizationConnectionId(id string) (connectionKey, error) {
+	parts, err := organizationConnectionIdFormat.parse(id)
 	if err != nil {
-		if r.StatusCode == 404 {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("Connection (%s) not found", connectionHandle),
-			})
-			d.SetId("")
-			return diags
-		}
-		return diag.Errorf("resourceOrganizationConnectionRead. Get connection error: %v", decodeResponse(r))
+		return connectionKey{}, err
 	}
-
-	// Convert config to string
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceOrganizationConnectionRead. Error converting config to string: %v", err)
-		}
-	}
-
-	_, writeConfig := d.GetOk("config")
-
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("organization_id", resp.IdentityId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	// computed connection state fields
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempted_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempted_at_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	d.Set("organization", orgId)
-	d.SetId(fmt.Sprintf("%s/%s", orgId, *resp.Handle))
-
-	return diags
+	return connectionKey{organization: parts["org-handle"], handle: parts["connection-handle"]}, nil
 }
 
-func resourceOrganizationConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	var orgHandle, configString string
-	var config map[string]interface{}
-	var writeConfig bool
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
-	// Get details about the organization where the integration would be created
-	if val, ok := d.GetOk("organization"); ok {
-		orgHandle = val.(string)
-	}
-
-	oldConnectionHandle, newConnectionHandle := d.GetChange("handle")
-	if newConnectionHandle.(string) == "" {
-		return diag.Errorf("handle must be configured")
-	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
-
-	req := pipes.UpdateConnectionRequest{Handle: types.String(newConnectionHandle.(string))}
-	if config != nil {
-		req.SetConfig(config)
-	}
-	if ok := d.HasChange("parent_id"); ok {
-		if value, ok := d.GetOk("parent_id"); ok {
-			req.SetParentId(value.(string))
-		}
-	}
-	if ok := d.HasChange("config_source"); ok {
-		if value, ok := d.GetOk("config_source"); ok {
-			req.SetConfigSource(pipes.ConnectionConfigSource(value.(string)))
-		}
-	}
-	if ok := d.HasChange("credential_source"); ok {
-		if value, ok := d.GetOk("credential_source"); ok {
-			req.SetCredentialSource(pipes.ConnectionCredentialSource(value.(string)))
-		}
-	}
+// A connection may also be imported by its ID alone, e.g. "c_cdi2d6c3ul7ugl1l6e30"
+var organizationConnectionImportIdFormat = newResourceIdFormat("<connection-id>", "<org-handle>/<connection-handle>")
 
-	resp, r, err := client.APIClient.OrgConnections.Update(context.Background(), orgHandle, oldConnectionHandle.(string)).Request(req).Execute()
+// resolveOrganizationConnectionId:: Resolve an organization connection imported by its ID, along with its organization
+// when imported by its ID alone
+func resolveOrganizationConnectionId(ctx context.Context, client *PipesClient, id resourceId) error {
+	connectionId, alone, err := importedConnectionId(id)
 	if err != nil {
-		return diag.Errorf("resourceOrganizationConnectionUpdate. Update connection error: %v", decodeResponse(r))
+		return err
 	}
-
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceOrganizationConnectionUpdate. Error converting config to string: %v", err)
-		}
+	if !alone {
+		return resolveConnectionHandle(ctx, client, id, organizationConnectionGet, connectionKey{organization: id["org-handle"], handle: id["connection-handle"]})
 	}
 
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("organization_id", resp.IdentityId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
+	conn, err := findActorConnection(ctx, client, connectionId)
+	if err != nil {
+		return err
 	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
+	identity, r, err := client.APIClient.Identities.Get(ctx, conn.GetIdentityId()).Execute()
+	if err = lookupError(conn.GetIdentityId(), r, err); err != nil {
+		return err
 	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
+	if identity.Type != "org" {
+		return fmt.Errorf("connection %s is not a connection of an organization", connectionId)
 	}
-	d.Set("version_id", resp.VersionId)
-	d.Set("organization", orgHandle)
-	d.SetId(fmt.Sprintf("%s/%s", orgHandle, *resp.Handle))
-
-	return diags
+	id["org-handle"], id["connection-handle"] = identity.Handle, conn.GetHandle()
+	return nil
 }
 
-func resourceOrganizationConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var orgHandle, connectionHandle string
+func formatOrganizationConnectionId(key connectionKey) string {
+	return fmt.Sprintf("%s/%s", key.organization, key.handle)
+}
 
-	// Get details about the organization where the integration would be created
-	if val, ok := d.GetOk("organization"); ok {
-		orgHandle = val.(string)
-	}
-	if value, ok := d.GetOk("handle"); ok {
-		connectionHandle = value.(string)
-	}
+func organizationConnectionCreate(ctx context.Context, client *PipesClient, key connectionKey, req pipes.CreateConnectionRequest) (pipes.Connection, *http.Response, error) {
+	return client.APIClient.OrgConnections.Create(ctx, key.organization).Request(req).Execute()
+}
 
-	_, r, err := client.APIClient.OrgConnections.Delete(ctx, orgHandle, connectionHandle).Execute()
-	if err != nil {
-		return diag.Errorf("resourceOrganizationConnectionDelete. Delete connection error:	%v", decodeResponse(r))
-	}
+func organizationConnectionGet(ctx context.Context, client *PipesClient, key connectionKey) (pipes.Connection, *http.Response, error) {
+	return client.APIClient.OrgConnections.Get(ctx, key.organization, key.handle).Execute()
+}
 
-	// clear the id to show we have deleted
-	d.SetId("")
+func organizationConnectionUpdate(ctx context.Context, client *PipesClient, key connectionKey, req pipes.UpdateConnectionRequest) (pipes.Connection, *http.Response, error) {
+	return client.APIClient.OrgConnections.Update(ctx, key.organization, key.handle).Request(req).Execute()
+}
 
-	return diags
+func organizationConnectionDelete(ctx context.Context, client *PipesClient, key connectionKey) (*http.Response, error) {
+	_, r, err := client.APIClient.OrgConnections.Delete(ctx, key.organization, key.handle).Execute()
+	return r, err
 }
@@ -4,22 +4,24 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var organizationConnectionFolderIdFormat = newResourceIdFormat("<org-handle>/<connection-folder-id>")
+
+// A connection folder may also be imported by its ID alone, e.g. "c_cqlp0647sic7l5q2n5d0"
+var organizationConnectionFolderImportIdFormat = newResourceIdFormat("<folder-id>", "<org-handle>/<connection-folder-id>")
+
 func resourceOrganizationConnectionFolder() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationConnectionFolderCreate,
 		ReadContext:   resourceOrganizationConnectionFolderRead,
 		UpdateContext: resourceOrganizationConnectionFolderUpdate,
 		DeleteContext: resourceOrganizationConnectionFolderDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(organizationConnectionFolderImportIdFormat, resolveOrganizationConnectionFolderId),
 		Schema: map[string]*schema.Schema{
 			"connection_folder_id": {
 				Type:     schema.TypeString,
@@ -173,22 +175,14 @@ func resourceOrganizationConnectionFolderRead(ctx context.Context, d *schema.Res
 	client := meta.(*PipesClient)
 
 	// Warning or errors can be collected in a slice type
-	var connectionFolderId, orgHandle string
 	var diags diag.Diagnostics
 
 	// Id would be of format "OrganizationHandle/ConnectionFolderId"
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 2 {
-		orgHandle = ids[0]
-		connectionFolderId = ids[1]
-	}
-
-	if orgHandle == "" {
-		return diag.Errorf("resourceOrganizationConnectionFolderRead. Organization information not present.")
-	}
-	if connectionFolderId == "" {
-		return diag.Errorf("resourceOrganizationConnectionFolderRead. Connection folder id not present.")
+	id, err := organizationConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, connectionFolderId := id["org-handle"], id["connection-folder-id"]
 
 	resp, r, err := client.APIClient.OrgConnectionFolders.Get(context.Background(), orgHandle, connectionFolderId).Execute()
 	if err != nil {
@@ -234,12 +228,11 @@ func resourceOrganizationConnectionFolderRead(ctx context.Context, d *schema.Res
 func resourceOrganizationConnectionFolderUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*PipesClient)
 
-	var connectionFolderId, orgHandle string
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 2 {
-		orgHandle = ids[0]
-		connectionFolderId = ids[1]
+	id, err := organizationConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, connectionFolderId := id["org-handle"], id["connection-folder-id"]
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -297,12 +290,11 @@ func resourceOrganizationConnectionFolderDelete(ctx context.Context, d *schema.R
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var connectionFolderId, orgHandle string
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 2 {
-		orgHandle = ids[0]
-		connectionFolderId = ids[1]
+	id, err := organizationConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, connectionFolderId := id["org-handle"], id["connection-folder-id"]
 
 	_, r, err := client.APIClient.OrgConnectionFolders.Delete(ctx, orgHandle, connectionFolderId).Execute()
 	if err != nil {
@@ -314,3 +306,26 @@ func resourceOrganizationConnectionFolderDelete(ctx context.Context, d *schema.R
 
 	return diags
 }
+
+// resolveOrganizationConnectionFolderId:: Resolve the organization of an organization connection folder imported by its
+// ID alone, looked up in the connection tree of the actor
+func resolveOrganizationConnectionFolderId(ctx context.Context, client *PipesClient, id resourceId) error {
+	folderId, alone, err := importedConnectionFolderId(id)
+	if err != nil || !alone {
+		return err
+	}
+
+	folder, err := findActorConnection(ctx, client, folderId)
+	if err != nil {
+		return err
+	}
+	identity, r, err := client.APIClient.Identities.Get(ctx, folder.GetIdentityId()).Execute()
+	if err = lookupError(folder.GetIdentityId(), r, err); err != nil {
+		return err
+	}
+	if identity.Type != "org" {
+		return fmt.Errorf("connection folder %s is not a connection folder of an organization", folderId)
+	}
+	id["org-handle"], id["connection-folder-id"] = identity.Handle, folder.Id
+	return nil
+}
@@ -4,22 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var organizationConnectionFolderPermissionIdFormat = newResourceIdFormat("<org-handle>/<connection-folder-id>/<permission-id>")
+
 func resourceOrganizationConnectionFolderPermission() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationConnectionFolderPermissionCreate,
 		ReadContext:   resourceOrganizationConnectionFolderPermissionRead,
 		UpdateContext: resourceOrganizationConnectionFolderPermissionUpdate,
 		DeleteContext: resourceOrganizationConnectionFolderPermissionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(organizationConnectionFolderPermissionIdFormat),
 		Schema: map[string]*schema.Schema{
 			"permission_id": {
 				Type:     schema.TypeString,
@@ -177,17 +176,16 @@ func resourceOrganizationConnectionFolderPermissionRead(ctx context.Context, d *
 
 	// ID formats
 	// Tenant Connection - "OrganizationHandle/ConnectionFolderId/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization-handle>/<connection-folder-id>/<permission-id>", d.Id())
+	idParts, err := organizationConnectionFolderPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	orgHandle = idParts[0]
-	connectionFolderId = idParts[1]
-	permissionId = idParts[2]
+	orgHandle = idParts["org-handle"]
+	connectionFolderId = idParts["connection-folder-id"]
+	permissionId = idParts["permission-id"]
 
 	var resp pipes.Permission
-	var err error
 	var r *http.Response
 
 	resp, r, err = client.APIClient.OrgConnectionFolders.GetPermission(ctx, orgHandle, connectionFolderId, permissionId).Execute()
@@ -237,13 +235,13 @@ func resourceOrganizationConnectionFolderPermissionUpdate(ctx context.Context, d
 
 	// ID formats
 	// Tenant Connection - "OrganizationHandle/ConnectionFolderId/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization-handle>/<connection-folder-id>/<permission-id>", d.Id())
+	idParts, err := organizationConnectionFolderPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	orgHandle = idParts[0]
-	permissionId = idParts[2]
+	orgHandle = idParts["org-handle"]
+	permissionId = idParts["permission-id"]
 
 	// When attaching a workspace schema, we can pass in a connection folder id, connection handle or aggregator handle
 	// Its already verified as part of schema validation rules that only one of these can be defined in configuration
@@ -320,16 +318,15 @@ func resourceOrganizationConnectionFolderPermissionDelete(ctx context.Context, d
 
 	// ID formats
 	// Tenant Connection - "OrganizationHandle/ConnectionFolderId/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization-handle>/<connection-folder-id>/<permission-id>", d.Id())
+	idParts, err := organizationConnectionFolderPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	orgHandle = idParts[0]
-	connectionFolderId = idParts[1]
-	permissionId = idParts[2]
+	orgHandle = idParts["org-handle"]
+	connectionFolderId = idParts["connection-folder-id"]
+	permissionId = idParts["permission-id"]
 
-	var err error
 	var r *http.Response
 
 	_, r, err = client.APIClient.OrgConnectionFolders.DeletePermission(ctx, orgHandle, connectionFolderId, permissionId).Execute()
@@ -36,6 +36,12 @@ func TestAccOrgConnectionFolder_Basic(t *testing.T) {
 				ImportState:  true,
 				// ImportStateVerify: true,
 			},
+			{
+				ResourceName:      folderName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFromAttribute(folderName, "connection_folder_id"),
+				ImportStateCheck:  testAccCheckImportedFolderId(),
+			},
 			{
 				Config: testAccOrgConnectionFolderUpdateConfig(orgHandle, updatedTitle),
 				Check: resource.ComposeTestCheckFunc(
@@ -4,22 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var organizationConnectionPermissionIdFormat = newResourceIdFormat("<org-handle>/<connection-handle>/<permission-id>")
+
 func resourceOrganizationConnectionPermission() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationConnectionPermissionCreate,
 		ReadContext:   resourceOrganizationConnectionPermissionRead,
 		UpdateContext: resourceOrganizationConnectionPermissionUpdate,
 		DeleteContext: resourceOrganizationConnectionPermissionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(organizationConnectionPermissionIdFormat, resolveOrganizationConnectionId),
 		Schema: map[string]*schema.Schema{
 			"permission_id": {
 				Type:     schema.TypeString,
@@ -177,17 +176,16 @@ func resourceOrganizationConnectionPermissionRead(ctx context.Context, d *schema
 
 	// ID formats
 	// Tenant Connection - "OrganizationHandle/ConnectionHandle/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization-handle>/<connection-handle>/<permission-id>", d.Id())
+	idParts, err := organizationConnectionPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	orgHandle = idParts[0]
-	connectionHandle = idParts[1]
-	permissionId = idParts[2]
+	orgHandle = idParts["org-handle"]
+	connectionHandle = idParts["connection-handle"]
+	permissionId = idParts["permission-id"]
 
 	var resp pipes.Permission
-	var err error
 	var r *http.Response
 
 	resp, r, err = client.APIClient.OrgConnections.GetPermission(ctx, orgHandle, connectionHandle, permissionId).Execute()
@@ -237,13 +235,13 @@ func resourceOrganizationConnectionPermissionUpdate(ctx context.Context, d *sche
 
 	// ID formats
 	// Tenant Connection - "OrganizationHandle/ConnectionHandle/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization-handle>/<connection-handle>/<permission-id>", d.Id())
+	idParts, err := organizationConnectionPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	orgHandle = idParts[0]
-	permissionId = idParts[2]
+	orgHandle = idParts["org-handle"]
+	permissionId = idParts["permission-id"]
 
 	// When attaching a workspace schema, we can pass in a connection folder id, connection handle or aggregator handle
 	// Its already verified as part of schema validation rules that only one of these can be defined in configuration
@@ -320,16 +318,15 @@ func resourceOrganizationConnectionPermissionDelete(ctx context.Context, d *sche
 
 	// ID formats
 	// Tenant Connection - "OrganizationHandle/ConnectionHandle/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization-handle>/<connection-handle>/<permission-id>", d.Id())
+	idParts, err := organizationConnectionPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	orgHandle = idParts[0]
-	connectionHandle = idParts[1]
-	permissionId = idParts[2]
+	orgHandle = idParts["org-handle"]
+	connectionHandle = idParts["connection-handle"]
+	permissionId = idParts["permission-id"]
 
-	var err error
 	var r *http.Response
 
 	_, r, err = client.APIClient.OrgConnections.DeletePermission(ctx, orgHandle, connectionHandle, permissionId).Execute()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -38,6 +39,10 @@ func TestAccOrgConnection_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "config", "{\n \"access_key\": \"redacted\",\n \"regions\": [\n  \"us-east-2\",\n  \"us-east-1\"\n ],\n \"secret_key\": \"redacted\"\n}"),
 				),
 			},
+			{
+				Config:      testAccOrgConnectionMisspelledConfig(newHandle, orgHandle),
+				ExpectError: regexp.MustCompile(`unsupported argument "region" for plugin aws, did you mean "regions"\?`),
+			},
 		},
 	})
 }
@@ -61,6 +66,25 @@ resource "pipes_organization_connection" "test_org" {
 }`, orgHandle, connHandle)
 }
 
+func testAccOrgConnectionMisspelledConfig(newHandle string, orgHandle string) string {
+	return fmt.Sprintf(`
+resource "pipes_organization" "test" {
+	handle       = "%s"
+	display_name = "Terraform Test Org"
+}
+
+resource "pipes_organization_connection" "test_org" {
+	organization = pipes_organization.test.handle
+	handle       = "%s"
+	plugin       = "aws"
+	config = jsonencode({
+		region       = ["us-east-1"]
+		access_key   = "redacted"
+		secret_key   = "redacted"
+	})
+}`, orgHandle, newHandle)
+}
+
 func testAccOrgConnectionUpdateConfig(newHandle string, orgHandle string) string {
 	return fmt.Sprintf(`
 resource "pipes_organization" "test" {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,15 +12,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var organizationIntegrationIdFormat = newResourceIdFormat("<org-handle>/<integration-handle>")
+
 func resourceOrganizationIntegration() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationIntegrationCreate,
 		ReadContext:   resourceOrganizationIntegrationRead,
 		UpdateContext: resourceOrganizationIntegrationUpdate,
 		DeleteContext: resourceOrganizationIntegrationDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(organizationIntegrationIdFormat),
 		Schema: map[string]*schema.Schema{
 			"integration_id": {
 				Type:     schema.TypeString,
@@ -205,20 +204,14 @@ func resourceOrganizationIntegrationRead(ctx context.Context, d *schema.Resource
 	client := meta.(*PipesClient)
 
 	// Warning or errors can be collected in a slice type
-	var integrationHandle, orgHandle, configString string
+	var configString string
 	var diags diag.Diagnostics
-	var err error
-	id := d.Id()
 
-	ids := strings.Split(id, "/")
-	if len(ids) == 2 {
-		orgHandle = ids[0]
-		integrationHandle = ids[1]
-	}
-
-	if integrationHandle == "" {
-		return diag.Errorf("resourceOrganizationIntegrationRead. Integration details is not present.")
+	id, err := organizationIntegrationIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, integrationHandle := id["org-handle"], id["integration-handle"]
 
 	resp, r, err := client.APIClient.OrgIntegrations.Get(context.Background(), orgHandle, integrationHandle).Execute()
 	if err != nil {
@@ -13,15 +13,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var organizationMemberIdFormat = newResourceIdFormat("<org-handle>/<user-handle>").withLegacySeparator(":")
+
 func resourceOrganizationMember() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationMemberCreate,
 		ReadContext:   resourceOrganizationMemberRead,
 		DeleteContext: resourceOrganizationMemberDelete,
 		UpdateContext: resourceOrganizationMemberUpdate,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(organizationMemberIdFormat),
 		Schema: map[string]*schema.Schema{
 			"user_handle": {
 				Type:          schema.TypeString,
@@ -198,21 +198,16 @@ func resourceOrganizationMemberRead(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 
 	id := d.Id()
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(id, ":") {
-		separator = ":"
-	}
-	idParts := strings.Split(id, separator)
-	if len(idParts) < 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization_handle>/<user_handle>", id)
+	idParts, err := organizationMemberIdFormat.parse(id)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	org := idParts[0]
+	org := idParts["org-handle"]
 
-	if strings.Contains(idParts[1], "@") {
+	if strings.Contains(idParts["user-handle"], "@") {
 		return diag.Errorf("invalid user_handle. Please provide valid user_handle to import")
 	}
-	userHandle := idParts[1]
+	userHandle := idParts["user-handle"]
 
 	resp, r, err := client.APIClient.OrgMembers.Get(context.Background(), org, userHandle).Execute()
 	if err != nil {
@@ -225,9 +220,8 @@ func resourceOrganizationMemberRead(ctx context.Context, d *schema.ResourceData,
 	}
 	log.Printf("\n[DEBUG] Organization Member received: %s", id)
 
-	if separator == ":" {
-		d.SetId(strings.ReplaceAll(id, ":", "/"))
-	}
+	// Rewrite an ID with the legacy : separator
+	d.SetId(organizationMemberIdFormat.format(idParts))
 	d.Set("user_handle", resp.UserHandle)
 	d.Set("created_at", resp.CreatedAt)
 	d.Set("organization_member_id", resp.Id)
@@ -311,20 +305,15 @@ func resourceOrganizationMemberDelete(ctx context.Context, d *schema.ResourceDat
 	var diags diag.Diagnostics
 
 	id := d.Id()
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(id, ":") {
-		separator = ":"
-	}
-	idParts := strings.Split(id, separator)
-	if len(idParts) < 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization_handle>/<user_handle>", id)
+	idParts, err := organizationMemberIdFormat.parse(id)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	org := idParts[0]
+	org := idParts["org-handle"]
 
 	log.Printf("\n[DEBUG] Removing membership: %s", id)
 
-	_, r, err := client.APIClient.OrgMembers.Delete(context.Background(), org, idParts[1]).Execute()
+	_, r, err := client.APIClient.OrgMembers.Delete(context.Background(), org, idParts["user-handle"]).Execute()
 	if err != nil {
 		return diag.Errorf("error removing membership %s: %s", id, decodeResponse(r))
 	}
@@ -0,0 +1,273 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/turbot/go-kit/types"
+	pipes "github.com/turbot/pipes-sdk-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceOrganizationMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrganizationMembersUpdate,
+		ReadContext:   resourceOrganizationMembersRead,
+		UpdateContext: resourceOrganizationMembersUpdate,
+		DeleteContext: resourceOrganizationMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOrganizationMembersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:         schema.TypeMap,
+				Required:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateMembers([]string{"member", "owner"}, true),
+			},
+			"invited_members": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceOrganizationMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*PipesClient)
+
+	orgHandle := d.Get("organization").(string)
+	members, r, err := listOrganizationMembers(ctx, client, orgHandle)
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			log.Printf("\n[WARN] Organization (%s) not found", orgHandle)
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error listing members of organization %s: %v", orgHandle, decodeResponse(r))
+	}
+
+	roles := map[string]string{}
+	invited := []string{}
+	for _, member := range members {
+		roles[member.UserHandle] = types.SafeString(member.Role)
+		if member.Status == "invited" {
+			invited = append(invited, member.UserHandle)
+		}
+		d.Set("organization_id", member.OrgId)
+	}
+	d.Set("members", roles)
+	d.Set("invited_members", invited)
+
+	return diags
+}
+
+// Invite the missing members, update the roles which changed and remove the members which are not in the configuration
+func resourceOrganizationMembersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	orgHandle := d.Get("organization").(string)
+	org, r, err := client.APIClient.Orgs.Get(ctx, orgHandle).Execute()
+	if err != nil {
+		return diag.Errorf("error reading organization %s: %s", orgHandle, decodeResponse(r))
+	}
+
+	members, r, err := listOrganizationMembers(ctx, client, org.Handle)
+	if err != nil {
+		return diag.Errorf("error listing members of organization %s: %v", org.Handle, decodeResponse(r))
+	}
+	current := map[string]string{}
+	invited := map[string]bool{}
+	for _, member := range members {
+		current[member.UserHandle] = types.SafeString(member.Role)
+		invited[member.UserHandle] = member.Status == "invited"
+	}
+	desired := membersFromConfig(d)
+
+	// Users of the primary tenant are invited, so new members are pending until they accept their invitation
+	pending := func(member string) bool {
+		if _, ok := current[member]; !ok {
+			return org.TenantId == PipesTenantId
+		}
+		return invited[member]
+	}
+	add, update, remove, err := membersChanges(current, desired, pending)
+	if err != nil {
+		return diag.Errorf("error updating members of organization %s: %v", org.Handle, err)
+	}
+
+	for _, userHandle := range add {
+		// Users of the primary tenant are invited, users of a custom tenant are added directly
+		if org.TenantId == PipesTenantId {
+			req := pipes.InviteOrgUserRequest{Handle: types.String(userHandle), Role: desired[userHandle]}
+			_, r, err = client.APIClient.OrgMembers.Invite(ctx, org.Handle).Request(req).Execute()
+		} else {
+			req := pipes.CreateOrgUserRequest{Handle: userHandle, Role: desired[userHandle]}
+			_, r, err = client.APIClient.OrgMembers.Create(ctx, org.Handle).Request(req).Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error inviting member %s to organization %s: %s", userHandle, org.Handle, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Member invited: %s/%s", org.Handle, userHandle)
+	}
+	for _, userHandle := range update {
+		req := pipes.UpdateOrgUserRequest{Role: desired[userHandle]}
+		_, r, err = client.APIClient.OrgMembers.Update(ctx, org.Handle, userHandle).Request(req).Execute()
+		if err != nil {
+			return diag.Errorf("error updating membership %s/%s: %s", org.Handle, userHandle, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Membership updated: %s/%s", org.Handle, userHandle)
+	}
+	for _, userHandle := range remove {
+		_, r, err = client.APIClient.OrgMembers.Delete(ctx, org.Handle, userHandle).Execute()
+		if err != nil {
+			return diag.Errorf("error removing membership %s/%s: %s", org.Handle, userHandle, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Membership removed: %s/%s", org.Handle, userHandle)
+	}
+
+	d.SetId(org.Handle)
+
+	return resourceOrganizationMembersRead(ctx, d, meta)
+}
+
+// The members are left as-is, including those added by the resource, since removing all of them would remove the last owner of the organization
+func resourceOrganizationMembersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+var organizationMembersIdFormat = newResourceIdFormat("<org-handle>")
+
+func resourceOrganizationMembersImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := resolveResourceId(ctx, meta.(*PipesClient), organizationMembersIdFormat, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("organization", id["org-handle"])
+	return []*schema.ResourceData{d}, nil
+}
+
+func listOrganizationMembers(ctx context.Context, client *PipesClient, orgHandle string) ([]pipes.OrgUser, *http.Response, error) {
+	var members []pipes.OrgUser
+	var nextToken string
+	for {
+		req := client.APIClient.OrgMembers.List(ctx, orgHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		if err != nil {
+			return nil, r, err
+		}
+		members = append(members, resp.GetItems()...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			return members, r, nil
+		}
+		nextToken = *resp.NextToken
+	}
+}
+
+func membersFromConfig(d *schema.ResourceData) map[string]string {
+	members := map[string]string{}
+	for key, role := range d.Get("members").(map[string]interface{}) {
+		members[key] = role.(string)
+	}
+	return members
+}
+
+// membersChanges:: The members to add, the members whose role changes and the members to remove for the current members to match the desired ones.
+// Promotions to owner are ordered before demotions, so that the members always keep an owner. Removing the last owner is refused, and so is
+// demoting or removing the last active owner when the only desired owners are pending, i.e. invited members who have not accepted their
+// invitation yet, as the members would be left without an owner able to manage them. pending may be nil when members are never invited.
+func membersChanges(current, desired map[string]string, pending func(member string) bool) ([]string, []string, []string, error) {
+	if countOwners(current) > 0 && countOwners(desired) == 0 {
+		return nil, nil, nil, fmt.Errorf("refusing to remove the last owner, at least one member must have the owner role")
+	}
+	if pending != nil {
+		active := func(members map[string]string) map[string]string {
+			result := map[string]string{}
+			for member, role := range members {
+				if !pending(member) {
+					result[member] = role
+				}
+			}
+			return result
+		}
+		if countOwners(active(current)) > 0 && countOwners(active(desired)) == 0 {
+			return nil, nil, nil, fmt.Errorf("refusing to demote or remove the last active owner while the desired owners have not accepted their invitation yet, keep an active member as owner until they do")
+		}
+	}
+
+	add, update, remove := []string{}, []string{}, []string{}
+	for key, role := range desired {
+		currentRole, ok := current[key]
+		if !ok {
+			add = append(add, key)
+		} else if currentRole != role {
+			update = append(update, key)
+		}
+	}
+	for key := range current {
+		if _, ok := desired[key]; !ok {
+			remove = append(remove, key)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	sort.Slice(update, func(i, j int) bool {
+		if (desired[update[i]] == "owner") != (desired[update[j]] == "owner") {
+			return desired[update[i]] == "owner"
+		}
+		return update[i] < update[j]
+	})
+	return add, update, remove, nil
+}
+
+func countOwners(members map[string]string) int {
+	count := 0
+	for _, role := range members {
+		if role == "owner" {
+			count++
+		}
+	}
+	return count
+}
+
+// validateMembers:: Validate the roles of a members map and, when requireOwner is set, that at least one member is an owner
+func validateMembers(roles []string, requireOwner bool) schema.SchemaValidateFunc {
+	return func(val interface{}, key string) (warns []string, errs []error) {
+		validRoles := map[string]bool{}
+		for _, role := range roles {
+			validRoles[role] = true
+		}
+		members := map[string]string{}
+		for member, role := range val.(map[string]interface{}) {
+			members[member] = role.(string)
+			if !validRoles[role.(string)] {
+				errs = append(errs, fmt.Errorf("%q: invalid role %q for %s, expected one of %s", key, role, member, strings.Join(roles, ", ")))
+			}
+		}
+		if requireOwner && countOwners(members) == 0 {
+			errs = append(errs, fmt.Errorf("%q: at least one member must have the owner role", key))
+		}
+		return
+	}
+}
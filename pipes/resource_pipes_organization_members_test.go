@@ -0,0 +1,227 @@
+// NOTE: Please provide a valid user handle in the config before performing the test
+
+package pipes
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// test suites
+func TestAccOrganizationMembers_Basic(t *testing.T) {
+	resourceName := "pipes_organization_members.test"
+	orgHandle := "terraform" + randomString(3)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOrganizationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationMembersConfig(orgHandle, "member"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "members.someuser", "member"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "invited_members.*", "someuser"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccOrganizationMembersConfig(orgHandle, "owner"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "members.someuser", "owner"),
+			},
+			{
+				Config:      testAccOrganizationMembersNoOwnerConfig(orgHandle),
+				ExpectError: regexp.MustCompile("at least one member must have the owner role"),
+			},
+		},
+	})
+}
+
+func TestMembersChanges(t *testing.T) {
+	invited := func(members ...string) func(string) bool {
+		return func(member string) bool {
+			for _, m := range members {
+				if m == member {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	cases := map[string]struct {
+		current, desired    map[string]string
+		pending             func(string) bool
+		add, update, remove []string
+		expected            string
+	}{
+		"no change": {
+			current: map[string]string{"alice": "owner", "bob": "member"},
+			desired: map[string]string{"alice": "owner", "bob": "member"},
+			add:     []string{}, update: []string{}, remove: []string{},
+		},
+		"add, update and remove": {
+			current: map[string]string{"alice": "owner", "bob": "member", "carol": "member"},
+			desired: map[string]string{"alice": "owner", "bob": "owner", "dave": "member"},
+			add:     []string{"dave"}, update: []string{"bob"}, remove: []string{"carol"},
+		},
+		"promotions to owner first": {
+			current: map[string]string{"alice": "owner", "bob": "member", "carol": "owner", "dave": "member"},
+			desired: map[string]string{"alice": "member", "bob": "owner", "carol": "member", "dave": "owner"},
+			add:     []string{}, update: []string{"bob", "dave", "alice", "carol"}, remove: []string{},
+		},
+		"handover to an active member": {
+			current: map[string]string{"alice": "owner", "bob": "member"},
+			desired: map[string]string{"bob": "owner"},
+			pending: invited(),
+			add:     []string{}, update: []string{"bob"}, remove: []string{"alice"},
+		},
+		"handover to a new member added directly": {
+			current: map[string]string{"alice": "owner"},
+			desired: map[string]string{"alice": "member", "bob": "owner"},
+			pending: invited(),
+			add:     []string{"bob"}, update: []string{"alice"}, remove: []string{},
+		},
+		"new owner invited alongside the active owner": {
+			current: map[string]string{"alice": "owner"},
+			desired: map[string]string{"alice": "owner", "bob": "owner"},
+			pending: invited("bob"),
+			add:     []string{"bob"}, update: []string{}, remove: []string{},
+		},
+		"no owner": {
+			current:  map[string]string{"alice": "owner", "bob": "member"},
+			desired:  map[string]string{"alice": "member", "bob": "member"},
+			expected: "refusing to remove the last owner",
+		},
+		"only desired owner is a newly invited user": {
+			current:  map[string]string{"alice": "owner"},
+			desired:  map[string]string{"bob": "owner"},
+			pending:  invited("bob"),
+			expected: "refusing to demote or remove the last active owner",
+		},
+		"only desired owner is a newly invited user and the active owner is demoted": {
+			current:  map[string]string{"alice": "owner"},
+			desired:  map[string]string{"alice": "member", "bob": "owner"},
+			pending:  invited("bob"),
+			expected: "refusing to demote or remove the last active owner",
+		},
+		"only desired owner has not accepted the invitation yet": {
+			current:  map[string]string{"alice": "owner", "bob": "member"},
+			desired:  map[string]string{"alice": "member", "bob": "owner"},
+			pending:  invited("bob"),
+			expected: "refusing to demote or remove the last active owner",
+		},
+		"members without invitations": {
+			current: map[string]string{"alice": "owner"},
+			desired: map[string]string{"bob": "owner"},
+			add:     []string{"bob"}, update: []string{}, remove: []string{"alice"},
+		},
+	}
+	for name, c := range cases {
+		add, update, remove, err := membersChanges(c.current, c.desired, c.pending)
+		if c.expected != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("%s: expected an error containing %q, got %v", name, c.expected, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(add, c.add) || !reflect.DeepEqual(update, c.update) || !reflect.DeepEqual(remove, c.remove) {
+			t.Errorf("%s: expected add %v, update %v, remove %v, got add %v, update %v, remove %v", name, c.add, c.update, c.remove, add, update, remove)
+		}
+	}
+}
+
+func TestValidateMembers(t *testing.T) {
+	cases := map[string]struct {
+		roles        []string
+		requireOwner bool
+		members      map[string]interface{}
+		expected     []string
+	}{
+		"valid":          {roles: []string{"member", "owner"}, requireOwner: true, members: map[string]interface{}{"alice": "owner", "bob": "member"}},
+		"invalid role":   {roles: []string{"member", "owner"}, requireOwner: true, members: map[string]interface{}{"alice": "owner", "bob": "admin"}, expected: []string{`invalid role "admin" for bob`}},
+		"no owner":       {roles: []string{"member", "owner"}, requireOwner: true, members: map[string]interface{}{"alice": "member"}, expected: []string{"at least one member must have the owner role"}},
+		"empty":          {roles: []string{"member", "owner"}, requireOwner: true, members: map[string]interface{}{}, expected: []string{"at least one member must have the owner role"}},
+		"owner optional": {roles: []string{"member", "owner"}, members: map[string]interface{}{"alice": "member"}},
+	}
+	for name, c := range cases {
+		_, errs := validateMembers(c.roles, c.requireOwner)(c.members, "members")
+		if len(errs) != len(c.expected) {
+			t.Errorf("%s: expected %d errors, got %v", name, len(c.expected), errs)
+			continue
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), c.expected[i]) {
+				t.Errorf("%s: expected an error containing %q, got %v", name, c.expected[i], err)
+			}
+		}
+	}
+}
+
+func TestMembersResourcesValidateRoles(t *testing.T) {
+	cases := map[string]struct {
+		resource *schema.Resource
+		valid    map[string]interface{}
+		invalid  map[string]interface{}
+	}{
+		"organization": {resourceOrganizationMembers(), map[string]interface{}{"alice": "owner", "bob": "member"}, map[string]interface{}{"alice": "owner", "bob": "reader"}},
+		"tenant":       {resourceTenantMembers(), map[string]interface{}{"alice@example.com": "owner", "bob@example.com": "member"}, map[string]interface{}{"alice@example.com": "owner", "bob@example.com": "admin"}},
+		"workspace":    {resourceOrganizationWorkspaceMembers(), map[string]interface{}{"alice": "owner", "bob": "admin", "carol": "reader"}, map[string]interface{}{"alice": "owner", "bob": "member"}},
+	}
+	for name, c := range cases {
+		validate := c.resource.Schema["members"].ValidateFunc
+		if _, errs := validate(c.valid, "members"); len(errs) != 0 {
+			t.Errorf("%s: unexpected errors %v", name, errs)
+		}
+		if _, errs := validate(c.invalid, "members"); len(errs) != 1 || !strings.Contains(errs[0].Error(), "invalid role") {
+			t.Errorf("%s: expected an invalid role error, got %v", name, errs)
+		}
+	}
+}
+
+// configs
+func testAccOrganizationMembersConfig(orgHandle, role string) string {
+	return fmt.Sprintf(`
+data "pipes_user" "current" {}
+
+resource "pipes_organization" "test" {
+	handle = "%s"
+}
+
+# Please provide a valid user handle
+resource "pipes_organization_members" "test" {
+	organization = pipes_organization.test.handle
+	members = {
+		(data.pipes_user.current.handle) = "owner"
+		someuser                         = "%s"
+	}
+}`, orgHandle, role)
+}
+
+func testAccOrganizationMembersNoOwnerConfig(orgHandle string) string {
+	return fmt.Sprintf(`
+resource "pipes_organization" "test" {
+	handle = "%s"
+}
+
+resource "pipes_organization_members" "test" {
+	organization = pipes_organization.test.handle
+	members = {
+		someuser = "member"
+	}
+}`, orgHandle)
+}
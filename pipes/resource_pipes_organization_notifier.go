@@ -2,29 +2,27 @@ package pipes
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/turbot/go-kit/types"
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var organizationNotifierIdFormat = newResourceIdFormat("<org-handle>/<notifier-name>")
+
 func resourceOrganizationNotifier() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationNotifierCreate,
 		ReadContext:   resourceOrganizationNotifierRead,
 		UpdateContext: resourceOrganizationNotifierUpdate,
 		DeleteContext: resourceOrganizationNotifierDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		CustomizeDiff: notifierCustomizeDiff,
+		Importer:      resourceIdImporter(organizationNotifierIdFormat, resolveOrganizationNotifierId),
 		Schema: map[string]*schema.Schema{
 			"organization": {
 				Type:     schema.TypeString,
@@ -34,11 +32,10 @@ func resourceOrganizationNotifier() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"notifies": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsJSON,
-			},
+			"notifies":            notifiesSchema(),
+			"notify":              notifySchema(),
+			"notifies_wo":         notifiesWriteOnlySchema(),
+			"notifies_wo_version": notifiesWriteOnlyVersionSchema(),
 			"notifier_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -109,13 +106,9 @@ func resourceOrganizationNotifierCreate(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	orgHandle := d.Get("organization").(string)
@@ -142,7 +135,7 @@ func resourceOrganizationNotifierCreate(ctx context.Context, d *schema.ResourceD
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -168,14 +161,11 @@ func resourceOrganizationNotifierRead(ctx context.Context, d *schema.ResourceDat
 	var resp pipes.Notifier
 
 	var orgHandle, notifierName string
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		orgHandle = parts[0]
-		notifierName = parts[1]
-	default:
-		return diag.Errorf("error parsing organization handle and notifier name from id: %s", d.Id())
+	id, err := organizationNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, notifierName = id["org-handle"], id["notifier-name"]
 
 	client := meta.(*PipesClient)
 
@@ -191,7 +181,7 @@ func resourceOrganizationNotifierRead(ctx context.Context, d *schema.ResourceDat
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -217,14 +207,11 @@ func resourceOrganizationNotifierUpdate(ctx context.Context, d *schema.ResourceD
 	var resp pipes.Notifier
 
 	var orgHandle, notifierName string
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		orgHandle = parts[0]
-		notifierName = parts[1]
-	default:
-		return diag.Errorf("error parsing organization handle and notifier name from id: %s", d.Id())
+	id, err := organizationNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, notifierName = id["org-handle"], id["notifier-name"]
 
 	s := d.Get("state").(string)
 	state, err := pipes.NewNotifierStateFromValue(s)
@@ -232,13 +219,9 @@ func resourceOrganizationNotifierUpdate(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	newNotifierName := notifierName
@@ -270,7 +253,7 @@ func resourceOrganizationNotifierUpdate(ctx context.Context, d *schema.ResourceD
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -297,14 +280,11 @@ func resourceOrganizationNotifierDelete(ctx context.Context, d *schema.ResourceD
 	client := meta.(*PipesClient)
 
 	var orgHandle, notifierName string
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		orgHandle = parts[0]
-		notifierName = parts[1]
-	default:
-		return diag.Errorf("error parsing orgnization handle and notifier name from id: %s", d.Id())
+	id, err := organizationNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, notifierName = id["org-handle"], id["notifier-name"]
 
 	_, r, err = client.APIClient.OrgNotifiers.Delete(ctx, orgHandle, notifierName).Execute()
 	if err != nil {
@@ -2,7 +2,6 @@ package pipes
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,6 +10,10 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var organizationServiceAccountIdFormat = newResourceIdFormat("<service-account-id>")
+
+var organizationServiceAccountImportIdFormat = newResourceIdFormat("<org-handle>/<service-account-id>")
+
 func resourceOrganizationServiceAccount() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationServiceAccountCreate,
@@ -18,20 +21,13 @@ func resourceOrganizationServiceAccount() *schema.Resource {
 		UpdateContext: resourceOrganizationServiceAccountUpdate,
 		DeleteContext: resourceOrganizationServiceAccountDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: func(ctx context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
-				// Expect import ID in the form <org_handle>/<service_account_identifier>
-				id := d.Id()
-				var orgHandle, saId string
-				for i := 0; i < len(id); i++ {
-					if id[i] == '/' {
-						orgHandle = id[:i]
-						saId = id[i+1:]
-						break
-					}
-				}
-				if orgHandle == "" || saId == "" {
-					return nil, fmt.Errorf("unexpected format of ID (%q), expected <org_handle>/<service_account_identifier>", id)
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// The import ID is of the form "OrgHandle/ServiceAccountId", the ID in the state only the service account
+				id, err := resolveResourceId(ctx, meta.(*PipesClient), organizationServiceAccountImportIdFormat, d.Id())
+				if err != nil {
+					return nil, err
 				}
+				orgHandle, saId := id["org-handle"], id["service-account-id"]
 				if err := d.Set("organization_handle", orgHandle); err != nil {
 					return nil, err
 				}
@@ -113,7 +109,11 @@ func resourceOrganizationServiceAccountRead(ctx context.Context, d *schema.Resou
 	var diags diag.Diagnostics
 
 	orgHandle := d.Get("organization_handle").(string)
-	saId := d.Id()
+	id, err := organizationServiceAccountIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	saId := id["service-account-id"]
 
 	resp, r, err := client.APIClient.OrgServiceAccounts.Get(ctx, orgHandle, saId).Execute()
 	if err != nil {
@@ -135,7 +135,11 @@ func resourceOrganizationServiceAccountUpdate(ctx context.Context, d *schema.Res
 	var diags diag.Diagnostics
 
 	orgHandle := d.Get("organization_handle").(string)
-	saId := d.Id()
+	id, err := organizationServiceAccountIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	saId := id["service-account-id"]
 
 	req := pipes.UpdateServiceAccountUserRequest{}
 	var hasUpdate bool
@@ -163,7 +167,11 @@ func resourceOrganizationServiceAccountDelete(ctx context.Context, d *schema.Res
 	var diags diag.Diagnostics
 
 	orgHandle := d.Get("organization_handle").(string)
-	saId := d.Id()
+	id, err := organizationServiceAccountIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	saId := id["service-account-id"]
 
 	r, err := client.APIClient.OrgServiceAccounts.Delete(ctx, orgHandle, saId).Execute()
 	if err != nil {
@@ -0,0 +1,269 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+var usageThresholdActions = []string{string(pipes.IdentityUsageThresholdActionWarn), string(pipes.IdentityUsageThresholdActionCapAndWarn)}
+
+func resourceOrganizationSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrganizationSettingsUpdate,
+		ReadContext:   resourceOrganizationSettingsRead,
+		UpdateContext: resourceOrganizationSettingsUpdate,
+		DeleteContext: resourceOrganizationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOrganizationSettingsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Tokens issued before this time are no longer accepted
+			"token_min_issued_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			// Usage thresholds and the action taken when they are reached
+			"usage_compute_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(usageThresholdActions, false),
+			},
+			"usage_compute_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"usage_storage_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(usageThresholdActions, false),
+			},
+			"usage_storage_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"usage_user_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(usageThresholdActions, false),
+			},
+			"usage_user_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			// Metadata
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceOrganizationSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*PipesClient)
+
+	orgHandle := d.Get("organization").(string)
+	resp, r, err := client.APIClient.Orgs.Get(ctx, orgHandle).Execute()
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Organization (%s) not found", orgHandle),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error reading settings of organization %s: %v", orgHandle, decodeResponse(r))
+	}
+
+	resourceOrganizationSettingsPopulateFromResponse(d, resp)
+
+	return diags
+}
+
+func resourceOrganizationSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	orgHandle := d.Get("organization").(string)
+	current, r, err := client.APIClient.Orgs.Get(ctx, orgHandle).Execute()
+	if err != nil {
+		return diag.Errorf("error reading settings of organization %s: %v", orgHandle, decodeResponse(r))
+	}
+
+	// Only send the settings which differ from the current settings of the organization
+	req := pipes.UpdateOrgRequest{}
+	changed := false
+
+	if v, ok := d.GetOk("token_min_issued_at"); ok {
+		val := v.(string)
+		if current.TokenMinIssuedAt == nil || *current.TokenMinIssuedAt != val {
+			req.TokenMinIssuedAt = &val
+			changed = true
+		}
+	}
+
+	if v, ok := d.GetOk("usage_compute_action"); ok {
+		val := pipes.IdentityUsageThresholdAction(v.(string))
+		if current.UsageComputeAction == nil || *current.UsageComputeAction != val {
+			req.UsageComputeAction = &val
+			changed = true
+		}
+	}
+	if val, ok := configuredThreshold(d, "usage_compute_threshold"); ok {
+		if current.UsageComputeThreshold == nil || *current.UsageComputeThreshold != val {
+			req.UsageComputeThreshold = &val
+			changed = true
+		}
+	}
+	if v, ok := d.GetOk("usage_storage_action"); ok {
+		val := pipes.IdentityUsageThresholdAction(v.(string))
+		if current.UsageStorageAction == nil || *current.UsageStorageAction != val {
+			req.UsageStorageAction = &val
+			changed = true
+		}
+	}
+	if val, ok := configuredThreshold(d, "usage_storage_threshold"); ok {
+		if current.UsageStorageThreshold == nil || *current.UsageStorageThreshold != val {
+			req.UsageStorageThreshold = &val
+			changed = true
+		}
+	}
+	if v, ok := d.GetOk("usage_user_action"); ok {
+		val := pipes.IdentityUsageThresholdAction(v.(string))
+		if current.UsageUserAction == nil || *current.UsageUserAction != val {
+			req.UsageUserAction = &val
+			changed = true
+		}
+	}
+	if val, ok := configuredThreshold(d, "usage_user_threshold"); ok {
+		if current.UsageUserThreshold == nil || *current.UsageUserThreshold != val {
+			req.UsageUserThreshold = &val
+			changed = true
+		}
+	}
+
+	resp := current
+	if changed {
+		resp, r, err = client.APIClient.Orgs.Update(ctx, orgHandle).Request(req).Execute()
+		if err != nil {
+			return diag.Errorf("error updating settings of organization %s: %v", orgHandle, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Settings updated for Organization: %s", orgHandle)
+	}
+
+	resourceOrganizationSettingsPopulateFromResponse(d, resp)
+
+	return nil
+}
+
+// configuredThreshold returns the usage threshold set in the configuration. A threshold of 0 is a valid value, which
+// GetOk would treat as unset.
+func configuredThreshold(d *schema.ResourceData, key string) (int64, bool) {
+	value := d.GetRawConfig().GetAttr(key)
+	if !value.IsKnown() || value.IsNull() {
+		return 0, false
+	}
+	val, _ := value.AsBigFloat().Int64()
+	return val, true
+}
+
+func resourceOrganizationSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// No API to delete settings; they are intrinsic to the organization.
+	// We leave current settings as-is and simply remove from state.
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
+
+// The settings of an organization are imported using the handle of the organization
+var organizationSettingsIdFormat = newResourceIdFormat("<org-handle>")
+
+func resourceOrganizationSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := resolveResourceId(ctx, meta.(*PipesClient), organizationSettingsIdFormat, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("organization", id["org-handle"])
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceOrganizationSettingsPopulateFromResponse(d *schema.ResourceData, resp pipes.Org) {
+	d.Set("organization", resp.Handle)
+	d.Set("organization_id", resp.Id)
+
+	// tokens
+	if resp.TokenMinIssuedAt != nil {
+		d.Set("token_min_issued_at", *resp.TokenMinIssuedAt)
+	}
+
+	// usage thresholds
+	if resp.UsageComputeAction != nil {
+		d.Set("usage_compute_action", string(*resp.UsageComputeAction))
+	}
+	if resp.UsageComputeThreshold != nil {
+		d.Set("usage_compute_threshold", *resp.UsageComputeThreshold)
+	}
+	if resp.UsageStorageAction != nil {
+		d.Set("usage_storage_action", string(*resp.UsageStorageAction))
+	}
+	if resp.UsageStorageThreshold != nil {
+		d.Set("usage_storage_threshold", *resp.UsageStorageThreshold)
+	}
+	if resp.UsageUserAction != nil {
+		d.Set("usage_user_action", string(*resp.UsageUserAction))
+	}
+	if resp.UsageUserThreshold != nil {
+		d.Set("usage_user_threshold", *resp.UsageUserThreshold)
+	}
+
+	// metadata
+	if resp.UpdatedAt != nil {
+		d.Set("updated_at", *resp.UpdatedAt)
+	}
+	if resp.UpdatedBy != nil {
+		d.Set("updated_by", resp.UpdatedBy.Handle)
+	}
+	d.Set("version_id", resp.VersionId)
+
+	d.SetId(resp.Handle)
+}
@@ -0,0 +1,67 @@
+package pipes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// test suites
+func TestAccOrganizationSettings_Basic(t *testing.T) {
+	resourceName := "pipes_organization_settings.test_settings"
+	orgHandle := "terraformsettings" + randomString(3)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOrganizationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationSettingsConfig(orgHandle, "warn", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "usage_compute_action", "warn"),
+					resource.TestCheckResourceAttr(resourceName, "usage_compute_threshold", "100"),
+					resource.TestCheckResourceAttrPair(resourceName, "organization_id", "pipes_organization.test_org", "organization_id"),
+					resource.TestCheckResourceAttrPair("data.pipes_organization_settings.test_settings", "usage_compute_threshold", resourceName, "usage_compute_threshold"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccOrganizationSettingsConfig(orgHandle, "cap_and_warn", 200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "usage_compute_action", "cap_and_warn"),
+					resource.TestCheckResourceAttr(resourceName, "usage_compute_threshold", "200"),
+				),
+			},
+			{
+				Config: testAccOrganizationSettingsConfig(orgHandle, "cap_and_warn", 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "usage_compute_threshold", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrganizationSettingsConfig(orgHandle, action string, threshold int) string {
+	return fmt.Sprintf(`
+	resource "pipes_organization" "test_org" {
+		handle       = "%s"
+		display_name = "Terraform Settings Test"
+	}
+
+	resource "pipes_organization_settings" "test_settings" {
+		organization            = pipes_organization.test_org.handle
+		usage_compute_action    = "%s"
+		usage_compute_threshold = %d
+	}
+
+	data "pipes_organization_settings" "test_settings" {
+		organization = pipes_organization_settings.test_settings.organization
+	}`, orgHandle, action, threshold)
+}
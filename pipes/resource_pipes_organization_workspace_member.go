@@ -12,15 +12,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var organizationWorkspaceMemberIdFormat = newResourceIdFormat("<org-handle>/<workspace-handle>/<user-handle>").withLegacySeparator(":")
+
 func resourceOrganizationWorkspaceMember() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOrganizationWorkspaceMemberCreate,
 		ReadContext:   resourceOrganizationWorkspaceMemberRead,
 		DeleteContext: resourceOrganizationWorkspaceMemberDelete,
 		UpdateContext: resourceOrganizationWorkspaceMemberUpdate,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(organizationWorkspaceMemberIdFormat),
 		Schema: map[string]*schema.Schema{
 			"organization_workspace_member_id": {
 				Type:     schema.TypeString,
@@ -180,22 +180,17 @@ func resourceOrganizationWorkspaceMemberRead(ctx context.Context, d *schema.Reso
 	var diags diag.Diagnostics
 
 	id := d.Id()
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(id, ":") {
-		separator = ":"
-	}
-	idParts := strings.Split(id, separator)
-	if len(idParts) < 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization_handle>/<workspace_handle>/<user_handle>", id)
+	idParts, err := organizationWorkspaceMemberIdFormat.parse(id)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	org := idParts[0]
-	workspace := idParts[1]
+	org := idParts["org-handle"]
+	workspace := idParts["workspace-handle"]
 
-	if strings.Contains(idParts[2], "@") {
+	if strings.Contains(idParts["user-handle"], "@") {
 		return diag.Errorf("invalid user_handle. Please provide valid user_handle to import")
 	}
-	user := idParts[2]
+	user := idParts["user-handle"]
 
 	orgWorkspaceMemberDetails, r, err := client.APIClient.OrgWorkspaceMembers.Get(context.Background(), org, workspace, user).Execute()
 	if err != nil {
@@ -209,9 +204,8 @@ func resourceOrganizationWorkspaceMemberRead(ctx context.Context, d *schema.Reso
 	log.Printf("\n[DEBUG] Organization Workspace Member received: %s", id)
 
 	// Set the property values
-	if separator == ":" {
-		d.SetId(strings.ReplaceAll(id, ":", "/"))
-	}
+	// Rewrite an ID with the legacy : separator
+	d.SetId(organizationWorkspaceMemberIdFormat.format(idParts))
 	d.Set("organization_workspace_member_id", orgWorkspaceMemberDetails.Id)
 	d.Set("organization_id", orgWorkspaceMemberDetails.OrgId)
 	d.Set("workspace_id", orgWorkspaceMemberDetails.WorkspaceId)
@@ -300,18 +294,13 @@ func resourceOrganizationWorkspaceMemberDelete(ctx context.Context, d *schema.Re
 	var diags diag.Diagnostics
 
 	id := d.Id()
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(id, ":") {
-		separator = ":"
-	}
-	idParts := strings.Split(id, separator)
-	if len(idParts) < 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <organization_handle>/<workspace_handle>/<user_handle>", id)
+	idParts, err := organizationWorkspaceMemberIdFormat.parse(id)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	org := idParts[0]
-	workspace := idParts[1]
-	user := idParts[2]
+	org := idParts["org-handle"]
+	workspace := idParts["workspace-handle"]
+	user := idParts["user-handle"]
 
 	log.Printf("\n[DEBUG] Removing membership: %s", id)
 
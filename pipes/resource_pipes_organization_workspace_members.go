@@ -0,0 +1,169 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/turbot/go-kit/types"
+	pipes "github.com/turbot/pipes-sdk-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var organizationWorkspaceMembersIdFormat = newResourceIdFormat("<org-handle>/<workspace-handle>")
+
+func resourceOrganizationWorkspaceMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrganizationWorkspaceMembersUpdate,
+		ReadContext:   resourceOrganizationWorkspaceMembersRead,
+		UpdateContext: resourceOrganizationWorkspaceMembersUpdate,
+		DeleteContext: resourceOrganizationWorkspaceMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOrganizationWorkspaceMembersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"workspace_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:         schema.TypeMap,
+				Required:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateMembers([]string{"reader", "admin", "owner"}, false),
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"workspace_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceOrganizationWorkspaceMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*PipesClient)
+
+	org := d.Get("organization").(string)
+	workspace := d.Get("workspace_handle").(string)
+	members, r, err := listOrganizationWorkspaceMembers(ctx, client, org, workspace)
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			log.Printf("\n[WARN] Workspace (%s) of organization (%s) not found", workspace, org)
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error listing members of workspace %s/%s: %v", org, workspace, decodeResponse(r))
+	}
+
+	roles := map[string]string{}
+	for _, member := range members {
+		roles[member.UserHandle] = types.SafeString(member.Role)
+		d.Set("organization_id", member.OrgId)
+		d.Set("workspace_id", member.WorkspaceId)
+	}
+	d.Set("members", roles)
+
+	return diags
+}
+
+// Add the missing members, update the roles which changed and remove the members which are not in the configuration
+func resourceOrganizationWorkspaceMembersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	org := d.Get("organization").(string)
+	workspace := d.Get("workspace_handle").(string)
+	members, r, err := listOrganizationWorkspaceMembers(ctx, client, org, workspace)
+	if err != nil {
+		return diag.Errorf("error listing members of workspace %s/%s: %v", org, workspace, decodeResponse(r))
+	}
+	current := map[string]string{}
+	for _, member := range members {
+		current[member.UserHandle] = types.SafeString(member.Role)
+	}
+	desired := membersFromConfig(d)
+
+	// Members of a workspace are added directly, without an invitation
+	add, update, remove, err := membersChanges(current, desired, nil)
+	if err != nil {
+		return diag.Errorf("error updating members of workspace %s/%s: %v", org, workspace, err)
+	}
+
+	for _, user := range add {
+		req := pipes.CreateOrgWorkspaceUserRequest{Handle: user, Role: desired[user]}
+		_, r, err = client.APIClient.OrgWorkspaceMembers.Create(ctx, org, workspace).Request(req).Execute()
+		if err != nil {
+			return diag.Errorf("error adding member %s to workspace %s/%s: %s", user, org, workspace, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Member added: %s/%s/%s", org, workspace, user)
+	}
+	for _, user := range update {
+		req := pipes.UpdateOrgWorkspaceUserRequest{Role: desired[user]}
+		_, r, err = client.APIClient.OrgWorkspaceMembers.Update(ctx, org, workspace, user).Request(req).Execute()
+		if err != nil {
+			return diag.Errorf("error updating membership %s/%s/%s: %s", org, workspace, user, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Membership updated: %s/%s/%s", org, workspace, user)
+	}
+	for _, user := range remove {
+		_, r, err = client.APIClient.OrgWorkspaceMembers.Delete(ctx, org, workspace, user).Execute()
+		if err != nil {
+			return diag.Errorf("error removing membership %s/%s/%s: %s", org, workspace, user, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Membership removed: %s/%s/%s", org, workspace, user)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", org, workspace))
+
+	return resourceOrganizationWorkspaceMembersRead(ctx, d, meta)
+}
+
+// The members are left as-is, since removing all of them would remove the last owner of the workspace
+// The members are left as-is, including those added by the resource, since removing all of them would remove the last owner of the workspace
+func resourceOrganizationWorkspaceMembersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func resourceOrganizationWorkspaceMembersImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	idParts, err := resolveResourceId(ctx, meta.(*PipesClient), organizationWorkspaceMembersIdFormat, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("organization", idParts["org-handle"])
+	d.Set("workspace_handle", idParts["workspace-handle"])
+	return []*schema.ResourceData{d}, nil
+}
+
+func listOrganizationWorkspaceMembers(ctx context.Context, client *PipesClient, org, workspace string) ([]pipes.OrgWorkspaceUser, *http.Response, error) {
+	var members []pipes.OrgWorkspaceUser
+	var nextToken string
+	for {
+		req := client.APIClient.OrgWorkspaceMembers.List(ctx, org, workspace)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		if err != nil {
+			return nil, r, err
+		}
+		members = append(members, resp.GetItems()...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			return members, r, nil
+		}
+		nextToken = *resp.NextToken
+	}
+}
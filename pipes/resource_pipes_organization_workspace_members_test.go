@@ -0,0 +1,87 @@
+// NOTE: Please provide a valid email in the config before performing the test
+
+package pipes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// test suites
+func TestAccOrganizationWorkspaceMembers_Basic(t *testing.T) {
+	resourceName := "pipes_organization_workspace_members.test"
+	orgHandle := "terraform" + randomString(3)
+	workspaceHandle := "dev" + randomString(3)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOrganizationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationWorkspaceMembersConfig(orgHandle, workspaceHandle, "reader"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembershipWorkspaceExists(orgHandle, workspaceHandle),
+					resource.TestCheckResourceAttr(resourceName, "members.%", "2"),
+					resource.TestCheckResourceAttrPair(resourceName, "workspace_id", "pipes_workspace.test", "workspace_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccOrganizationWorkspaceMembersConfig(orgHandle, workspaceHandle, "admin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "members.%", "2"),
+					testAccCheckOrganizationWorkspaceMembersRole(resourceName, "pipes_organization_member.test", "admin"),
+				),
+			},
+		},
+	})
+}
+
+// configs
+func testAccOrganizationWorkspaceMembersConfig(orgHandle, workspaceHandle, role string) string {
+	return fmt.Sprintf(`
+data "pipes_user" "current" {}
+
+resource "pipes_organization" "test" {
+	handle = "%s"
+}
+
+resource "pipes_workspace" "test" {
+	organization = pipes_organization.test.handle
+	handle       = "%s"
+}
+
+# Invite the user to the organization
+resource "pipes_organization_member" "test" {
+	organization = pipes_organization.test.handle
+	email        = "user@domain.com"
+	role         = "member"
+}
+
+resource "pipes_organization_workspace_members" "test" {
+	organization     = pipes_organization.test.handle
+	workspace_handle = pipes_workspace.test.handle
+	members = {
+		(data.pipes_user.current.handle)             = "owner"
+		(pipes_organization_member.test.user_handle) = "%s"
+	}
+}`, orgHandle, workspaceHandle, role)
+}
+
+// helper functions
+func testAccCheckOrganizationWorkspaceMembersRole(resourceName, memberResourceName, role string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		member, ok := state.RootModule().Resources[memberResourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", memberResourceName)
+		}
+		return resource.TestCheckResourceAttr(resourceName, "members."+member.Primary.Attributes["user_handle"], role)(state)
+	}
+}
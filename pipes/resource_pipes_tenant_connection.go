@@ -5,26 +5,28 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/turbot/go-kit/types"
 	"github.com/turbot/pipes-sdk-go"
 )
 
 func resourceTenantConnection() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceTenantConnectionCreate,
-		ReadContext:   resourceTenantConnectionRead,
-		UpdateContext: resourceTenantConnectionUpdate,
-		DeleteContext: resourceTenantConnectionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
+	return connectionResource(connectionScope{
+		name:           "resourceTenantConnection",
+		config:         connectionJSONConfig,
+		removeNotFound: true,
+		key:            tenantConnectionKey,
+		parseId:        parseTenantConnectionId,
+		formatId:       formatTenantConnectionId,
+		importFormat:   tenantConnectionImportIdFormat,
+		resolveId:      resolveTenantConnectionId,
+		create:         tenantConnectionCreate,
+		get:            tenantConnectionGet,
+		update:         tenantConnectionUpdate,
+		delete:         tenantConnectionDelete,
+		attributes: map[string]*schema.Schema{
 			"connection_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -181,358 +183,67 @@ func resourceTenantConnection() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
-func resourceTenantConnectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var plugin, connHandle, configString, parentId string
-	var config map[string]interface{}
-	var err error
-	var writeConfig bool
-
-	// Get general information about the connection to be created
-	if value, ok := d.GetOk("handle"); ok {
-		connHandle = value.(string)
-	}
-	if value, ok := d.GetOk("plugin"); ok {
-		plugin = value.(string)
-	}
-	if value, ok := d.GetOk("parent_id"); ok {
-		parentId = value.(string)
-	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
-
-	req := pipes.CreateConnectionRequest{
-		Handle: connHandle,
-		Plugin: plugin,
-	}
-
-	// Pass the parent_id if its set
-	if parentId != "" {
-		req.SetParentId(parentId)
-	}
-
-	// Pass the config if its set
-	if config != nil {
-		req.SetConfig(config)
-	}
-
-	client := meta.(*PipesClient)
-	var resp pipes.Connection
-	var r *http.Response
-
-	resp, r, err = client.APIClient.TenantConnections.Create(ctx).Request(req).Execute()
-	if err != nil {
-		return diag.Errorf("resourceTenantConnectionCreate. Create connection api error  %v", decodeResponse(r))
-	}
-
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceTenantConnectionCreate. Error converting config to string: %v", err)
-		}
+// The connection is created at a custom tenant level
+// The id would be of format "TenantId/ConnectionHandle"
+func tenantConnectionKey(d *schema.ResourceData) connectionKey {
+	return connectionKey{
+		tenant: d.Get("tenant_id").(string),
+		handle: d.Get("handle").(string),
 	}
-
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	// The connection is being created at a custom tenant level
-	// The id would be of format "TenantId/ConnectionHandle"
-	d.SetId(fmt.Sprintf("%s/%s", resp.TenantId, *resp.Handle))
-
-	return diags
 }
 
-func resourceTenantConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	// Warning or errors can be collected in a slice type
-	var connectionHandle, tenantId, configString string
-	var diags diag.Diagnostics
-
-	// Its a tenant level connection so the id would be of format "TenantId/ConnectionHandle"
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 2 {
-		tenantId = ids[0]
-		connectionHandle = ids[1]
-	}
-
-	if tenantId == "" {
-		return diag.Errorf("resourceTenantConnectionRead. Tenant information not present.")
-	}
-	if connectionHandle == "" {
-		return diag.Errorf("resourceTenantConnectionRead. Connection handle not present.")
-	}
+var tenantConnectionIdFormat = newResourceIdFormat("<tenant-id>/<connection-handle>")
 
-	resp, r, err := client.APIClient.TenantConnections.Get(context.Background(), connectionHandle).Execute()
+func parseTenantConnectionId(id string) (connectionKey, error) {
+	parts, err := tenantConnectionIdFormat.parse(id)
 	if err != nil {
-		if r.StatusCode == 404 {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("Connection (%s) not found", connectionHandle),
-			})
-			d.SetId("")
-			return diags
-		}
-		return diag.Errorf("resourceTenantConnectionRead. Get connection error: %v", decodeResponse(r))
-	}
-
-	// Convert config to string
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceTenantConnectionRead. Error converting config to string: %v", err)
-		}
-	}
-
-	_, writeConfig := d.GetOk("config")
-
-	// assign results back into ResourceData
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
+		return connectionKey{}, err
 	}
-	// The connection is being created at a custom tenant level
-	// The id would be of format "TenantId/ConnectionHandle"
-	d.SetId(fmt.Sprintf("%s/%s", resp.TenantId, *resp.Handle))
-
-	return diags
+	return connectionKey{tenant: parts["tenant-id"], handle: parts["connection-handle"]}, nil
 }
 
-func resourceTenantConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	var configString string
-	var config map[string]interface{}
-	var writeConfig bool
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
-	oldConnectionHandle, newConnectionHandle := d.GetChange("handle")
-	if newConnectionHandle.(string) == "" {
-		return diag.Errorf("handle must be configured")
-	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
-
-	req := pipes.UpdateConnectionRequest{Handle: types.String(newConnectionHandle.(string))}
-	if config != nil {
-		req.SetConfig(config)
-	}
-	if ok := d.HasChange("parent_id"); ok {
-		if value, ok := d.GetOk("parent_id"); ok {
-			req.SetParentId(value.(string))
-		}
-	}
-	if ok := d.HasChange("config_source"); ok {
-		if value, ok := d.GetOk("config_source"); ok {
-			req.SetConfigSource(pipes.ConnectionConfigSource(value.(string)))
-		}
-	}
-	if ok := d.HasChange("credential_source"); ok {
-		if value, ok := d.GetOk("credential_source"); ok {
-			req.SetCredentialSource(pipes.ConnectionCredentialSource(value.(string)))
-		}
-	}
+// A connection may also be imported by its ID alone, e.g. "c_cdi2d6c3ul7ugl1l6e30"
+var tenantConnectionImportIdFormat = newResourceIdFormat("<connection-id>", "<tenant-id>/<connection-handle>")
 
-	resp, r, err := client.APIClient.TenantConnections.Update(context.Background(), oldConnectionHandle.(string)).Request(req).Execute()
+// resolveTenantConnectionId:: Resolve a tenant connection imported by its ID, along with its tenant when imported by its
+// ID alone
+func resolveTenantConnectionId(ctx context.Context, client *PipesClient, id resourceId) error {
+	connectionId, alone, err := importedConnectionId(id)
 	if err != nil {
-		return diag.Errorf("resourceTenantConnectionUpdate. Update connection error: %v", decodeResponse(r))
+		return err
 	}
-
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceTenantConnectionUpdate. Error converting config to string: %v", err)
-		}
+	if !alone {
+		return resolveConnectionHandle(ctx, client, id, tenantConnectionGet, connectionKey{tenant: id["tenant-id"], handle: id["connection-handle"]})
 	}
 
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
+	conn, r, err := tenantConnectionGet(ctx, client, connectionKey{handle: connectionId})
+	if err = lookupError(connectionId, r, err); err != nil {
+		return err
 	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	// The connection is being created at a custom tenant level
-	// The id would be of format "TenantId/ConnectionHandle"
-	d.SetId(fmt.Sprintf("%s/%s", resp.TenantId, *resp.Handle))
-
-	return diags
+	id["tenant-id"], id["connection-handle"] = conn.TenantId, conn.GetHandle()
+	return nil
 }
 
-func resourceTenantConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
+func formatTenantConnectionId(key connectionKey) string {
+	return fmt.Sprintf("%s/%s", key.tenant, key.handle)
+}
 
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var connectionHandle string
-	if value, ok := d.GetOk("handle"); ok {
-		connectionHandle = value.(string)
-	}
+func tenantConnectionCreate(ctx context.Context, client *PipesClient, key connectionKey, req pipes.CreateConnectionRequest) (pipes.Connection, *http.Response, error) {
+	return client.APIClient.TenantConnections.Create(ctx).Request(req).Execute()
+}
 
-	_, r, err := client.APIClient.TenantConnections.Delete(ctx, connectionHandle).Execute()
-	if err != nil {
-		return diag.Errorf("resourceTenantConnectionDelete. Delete connection error:	%v", decodeResponse(r))
-	}
+func tenantConnectionGet(ctx context.Context, client *PipesClient, key connectionKey) (pipes.Connection, *http.Response, error) {
+	return client.APIClient.TenantConnections.Get(ctx, key.handle).Execute()
+}
 
-	// clear the id to show we have deleted
-	d.SetId("")
+func tenantConnectionUpdate(ctx context.Context, client *PipesClient, key connectionKey, req pipes.UpdateConnectionRequest) (pipes.Connection, *http.Response, error) {
+	return client.APIClient.TenantConnections.Update(ctx, key.handle).Request(req).Execute()
+}
 
-	return diags
+func tenantConnectionDelete(ctx context.Context, client *PipesClient, key connectionKey) (*http.Response, error) {
+	_, r, err := client.APIClient.TenantConnections.Delete(ctx, key.handle).Execute()
+	return r, err
 }
@@ -4,22 +4,24 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var tenantConnectionFolderIdFormat = newResourceIdFormat("<tenant-id>/<connection-folder-id>")
+
+// A connection folder may also be imported by its ID alone, e.g. "c_cqlp0647sic7l5q2n5d0"
+var tenantConnectionFolderImportIdFormat = newResourceIdFormat("<folder-id>", "<tenant-id>/<connection-folder-id>")
+
 func resourceTenantConnectionFolder() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantConnectionFolderCreate,
 		ReadContext:   resourceTenantConnectionFolderRead,
 		UpdateContext: resourceTenantConnectionFolderUpdate,
 		DeleteContext: resourceTenantConnectionFolderDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(tenantConnectionFolderImportIdFormat, resolveTenantConnectionFolderId),
 		Schema: map[string]*schema.Schema{
 			"connection_folder_id": {
 				Type:     schema.TypeString,
@@ -159,22 +161,14 @@ func resourceTenantConnectionFolderRead(ctx context.Context, d *schema.ResourceD
 	client := meta.(*PipesClient)
 
 	// Warning or errors can be collected in a slice type
-	var connectionFolderId, tenantId string
 	var diags diag.Diagnostics
 
 	// Its a tenant level connection so the id would be of format "TenantId/ConnectionFolderId"
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 2 {
-		tenantId = ids[0]
-		connectionFolderId = ids[1]
-	}
-
-	if tenantId == "" {
-		return diag.Errorf("resourceTenantConnectionFolderRead. Tenant information not present.")
-	}
-	if connectionFolderId == "" {
-		return diag.Errorf("resourceTenantConnectionFolderRead. Connection folder id not present.")
+	id, err := tenantConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	connectionFolderId := id["connection-folder-id"]
 
 	resp, r, err := client.APIClient.TenantConnectionFolders.Get(context.Background(), connectionFolderId).Execute()
 	if err != nil {
@@ -219,7 +213,11 @@ func resourceTenantConnectionFolderRead(ctx context.Context, d *schema.ResourceD
 func resourceTenantConnectionFolderUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*PipesClient)
 
-	connectionFolderId := strings.Split(d.Id(), "/")[1]
+	id, err := tenantConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connectionFolderId := id["connection-folder-id"]
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -276,7 +274,11 @@ func resourceTenantConnectionFolderDelete(ctx context.Context, d *schema.Resourc
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	connectionFolderId := strings.Split(d.Id(), "/")[1]
+	id, err := tenantConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connectionFolderId := id["connection-folder-id"]
 
 	_, r, err := client.APIClient.TenantConnectionFolders.Delete(ctx, connectionFolderId).Execute()
 	if err != nil {
@@ -288,3 +290,18 @@ func resourceTenantConnectionFolderDelete(ctx context.Context, d *schema.Resourc
 
 	return diags
 }
+
+// resolveTenantConnectionFolderId:: Resolve the tenant of a tenant connection folder imported by its ID alone
+func resolveTenantConnectionFolderId(ctx context.Context, client *PipesClient, id resourceId) error {
+	folderId, alone, err := importedConnectionFolderId(id)
+	if err != nil || !alone {
+		return err
+	}
+
+	folder, r, err := client.APIClient.TenantConnectionFolders.Get(ctx, folderId).Execute()
+	if err = lookupError(folderId, r, err); err != nil {
+		return err
+	}
+	id["tenant-id"], id["connection-folder-id"] = folder.TenantId, folder.Id
+	return nil
+}
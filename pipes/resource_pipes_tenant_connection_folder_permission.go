@@ -4,22 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var tenantConnectionFolderPermissionIdFormat = newResourceIdFormat("<connection-folder-id>/<permission-id>")
+
 func resourceTenantConnectionFolderPermission() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantConnectionFolderPermissionCreate,
 		ReadContext:   resourceTenantConnectionFolderPermissionRead,
 		UpdateContext: resourceTenantConnectionFolderPermissionUpdate,
 		DeleteContext: resourceTenantConnectionFolderPermissionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(tenantConnectionFolderPermissionIdFormat),
 		Schema: map[string]*schema.Schema{
 			"permission_id": {
 				Type:     schema.TypeString,
@@ -170,16 +169,15 @@ func resourceTenantConnectionFolderPermissionRead(ctx context.Context, d *schema
 
 	// ID formats
 	// Tenant Connection - "ConnectionFolderId/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <connection-handle>/<permission-id>", d.Id())
+	idParts, err := tenantConnectionFolderPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	connectionFolderId = idParts[0]
-	permissionId = idParts[1]
+	connectionFolderId = idParts["connection-folder-id"]
+	permissionId = idParts["permission-id"]
 
 	var resp pipes.Permission
-	var err error
 	var r *http.Response
 
 	resp, r, err = client.APIClient.TenantConnectionFolders.GetPermission(ctx, connectionFolderId, permissionId).Execute()
@@ -228,12 +226,12 @@ func resourceTenantConnectionFolderPermissionUpdate(ctx context.Context, d *sche
 
 	// ID formats
 	// Tenant Connection - "ConnectionFolderId/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <connection-folder-id>/<permission-id>", d.Id())
+	idParts, err := tenantConnectionFolderPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	permissionId = idParts[1]
+	permissionId = idParts["permission-id"]
 
 	// When attaching a workspace schema, we can pass in a connection folder id, connection handle or aggregator handle
 	// Its already verified as part of schema validation rules that only one of these can be defined in configuration
@@ -309,15 +307,14 @@ func resourceTenantConnectionFolderPermissionDelete(ctx context.Context, d *sche
 
 	// ID formats
 	// Tenant Connection - "ConnectionFolderId/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <connection-handle>/<permission-id>", d.Id())
+	idParts, err := tenantConnectionFolderPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	connectionFolderId = idParts[0]
-	permissionId = idParts[1]
+	connectionFolderId = idParts["connection-folder-id"]
+	permissionId = idParts["permission-id"]
 
-	var err error
 	var r *http.Response
 
 	_, r, err = client.APIClient.TenantConnectionFolders.DeletePermission(ctx, connectionFolderId, permissionId).Execute()
@@ -35,6 +35,12 @@ func TestAccTenantConnectionFolder_Basic(t *testing.T) {
 				ImportState:  true,
 				// ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFromAttribute(resourceName, "connection_folder_id"),
+				ImportStateCheck:  testAccCheckImportedFolderId(),
+			},
 			{
 				Config: testAccTenantConnectionFolderTitleUpdateConfig(updatedTitle),
 				Check: resource.ComposeTestCheckFunc(
@@ -4,22 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var tenantConnectionPermissionIdFormat = newResourceIdFormat("<connection-handle>/<permission-id>")
+
 func resourceTenantConnectionPermission() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantConnectionPermissionCreate,
 		ReadContext:   resourceTenantConnectionPermissionRead,
 		UpdateContext: resourceTenantConnectionPermissionUpdate,
 		DeleteContext: resourceTenantConnectionPermissionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(tenantConnectionPermissionIdFormat, resolveTenantConnectionId),
 		Schema: map[string]*schema.Schema{
 			"permission_id": {
 				Type:     schema.TypeString,
@@ -170,16 +169,15 @@ func resourceTenantConnectionPermissionRead(ctx context.Context, d *schema.Resou
 
 	// ID formats
 	// Tenant Connection - "ConnectionHandle/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <connection-handle>/<permission-id>", d.Id())
+	idParts, err := tenantConnectionPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	connectionHandle = idParts[0]
-	permissionId = idParts[1]
+	connectionHandle = idParts["connection-handle"]
+	permissionId = idParts["permission-id"]
 
 	var resp pipes.Permission
-	var err error
 	var r *http.Response
 
 	resp, r, err = client.APIClient.TenantConnections.GetPermission(ctx, connectionHandle, permissionId).Execute()
@@ -228,12 +226,12 @@ func resourceTenantConnectionPermissionUpdate(ctx context.Context, d *schema.Res
 
 	// ID formats
 	// Tenant Connection - "ConnectionHandle/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <connection-handle>/<permission-id>", d.Id())
+	idParts, err := tenantConnectionPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	permissionId = idParts[1]
+	permissionId = idParts["permission-id"]
 
 	// When attaching a workspace schema, we can pass in a connection folder id, connection handle or aggregator handle
 	// Its already verified as part of schema validation rules that only one of these can be defined in configuration
@@ -309,15 +307,14 @@ func resourceTenantConnectionPermissionDelete(ctx context.Context, d *schema.Res
 
 	// ID formats
 	// Tenant Connection - "ConnectionHandle/PermissionId"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) != 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <connection-handle>/<permission-id>", d.Id())
+	idParts, err := tenantConnectionPermissionIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	connectionHandle = idParts[0]
-	permissionId = idParts[1]
+	connectionHandle = idParts["connection-handle"]
+	permissionId = idParts["permission-id"]
 
-	var err error
 	var r *http.Response
 
 	_, r, err = client.APIClient.TenantConnections.DeletePermission(ctx, connectionHandle, permissionId).Execute()
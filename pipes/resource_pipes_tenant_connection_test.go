@@ -37,6 +37,12 @@ func TestAccTenantConnection_Basic(t *testing.T) {
 				ImportState:  true,
 				// ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFromAttribute(resourceName, "connection_id"),
+				ImportStateCheck:  testAccCheckImportedId(connHandle),
+			},
 			{
 				Config: testAccTenantConnectionHandleUpdateConfig(newHandle),
 				Check: resource.ComposeTestCheckFunc(
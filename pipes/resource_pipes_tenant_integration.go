@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,15 +12,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var tenantIntegrationIdFormat = newResourceIdFormat("<tenant-id>/<integration-id>").withLegacySeparator(":")
+
 func resourceTenantIntegration() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantIntegrationCreate,
 		ReadContext:   resourceTenantIntegrationRead,
 		UpdateContext: resourceTenantIntegrationUpdate,
 		DeleteContext: resourceTenantIntegrationDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(tenantIntegrationIdFormat),
 		Schema: map[string]*schema.Schema{
 			"integration_id": {
 				Type:     schema.TypeString,
@@ -190,32 +189,18 @@ func resourceTenantIntegrationRead(ctx context.Context, d *schema.ResourceData,
 	client := meta.(*PipesClient)
 
 	// Warning or errors can be collected in a slice type
-	var integrationId, tenantId, configString string
+	var configString string
 	var diags diag.Diagnostics
-	var err error
 	var r *http.Response
 	var resp pipes.Integration
-	id := d.Id()
-
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(id, ":") {
-		separator = ":"
-	}
-
-	// The id consists of parts in thr format "TenantHandle/IntegrationHandle"
-	ids := strings.Split(id, separator)
-	if len(ids) == 2 {
-		tenantId = ids[0]
-		integrationId = ids[1]
-	}
 
-	if tenantId == "" {
-		return diag.Errorf("resourceTenantIntegrationRead. Tenant information not present.")
-	}
-	if integrationId == "" {
-		return diag.Errorf("resourceTenantIntegrationRead. Integration information not present.")
+	// The id consists of parts in thr format "TenantHandle/IntegrationHandle", or for backward-compatibility
+	// "TenantHandle:IntegrationHandle"
+	id, err := tenantIntegrationIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	integrationId := id["integration-id"]
 
 	resp, r, err = client.APIClient.TenantIntegrations.Get(ctx, integrationId).Execute()
 	if err != nil {
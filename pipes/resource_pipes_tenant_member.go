@@ -12,15 +12,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var tenantMemberIdFormat = newResourceIdFormat("<tenant-handle>/<user-id>")
+
 func resourceTenantMember() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantMemberCreate,
 		ReadContext:   resourceTenantMemberRead,
 		DeleteContext: resourceTenantMemberDelete,
 		UpdateContext: resourceTenantMemberUpdate,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(tenantMemberIdFormat),
 		Schema: map[string]*schema.Schema{
 			"tenant_member_id": {
 				Type:     schema.TypeString,
@@ -142,17 +142,16 @@ func resourceTenantMemberRead(ctx context.Context, d *schema.ResourceData, meta
 	var diags diag.Diagnostics
 
 	id := d.Id()
-	separator := "/"
-	idParts := strings.Split(id, separator)
-	if len(idParts) < 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <tenant_handle>/<user_id>", id)
+	idParts, err := tenantMemberIdFormat.parse(id)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	tenantHandle := idParts[0]
+	tenantHandle := idParts["tenant-handle"]
 
-	if strings.Contains(idParts[1], "@") {
+	if strings.Contains(idParts["user-id"], "@") {
 		return diag.Errorf("invalid user_id. Please provide valid user_id to import")
 	}
-	userHandle := idParts[1]
+	userHandle := idParts["user-id"]
 
 	tenantMember, r, err := client.APIClient.TenantMembers.Get(context.Background(), tenantHandle, userHandle).Execute()
 	if err != nil {
@@ -255,13 +254,12 @@ func resourceTenantMemberDelete(ctx context.Context, d *schema.ResourceData, met
 	var diags diag.Diagnostics
 
 	id := d.Id()
-	separator := "/"
-	idParts := strings.Split(id, separator)
-	if len(idParts) < 2 {
-		return diag.Errorf("unexpected format of ID (%q), expected <tenant_handle>/<user_id>", id)
+	idParts, err := tenantMemberIdFormat.parse(id)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	tenantHandle := idParts[0]
-	userHandle := idParts[1]
+	tenantHandle := idParts["tenant-handle"]
+	userHandle := idParts["user-id"]
 
 	log.Printf("\n[DEBUG] Removing membership: %s", id)
 
@@ -0,0 +1,175 @@
+package pipes
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	pipes "github.com/turbot/pipes-sdk-go"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTenantMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTenantMembersUpdate,
+		ReadContext:   resourceTenantMembersRead,
+		UpdateContext: resourceTenantMembersUpdate,
+		DeleteContext: resourceTenantMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTenantMembersImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Type:         schema.TypeMap,
+				Required:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateMembers([]string{"member", "owner"}, true),
+			},
+			"invited_members": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTenantMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*PipesClient)
+
+	tenantHandle := d.Get("tenant_handle").(string)
+	members, r, err := listTenantMembers(ctx, client, tenantHandle)
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			log.Printf("\n[WARN] Tenant (%s) not found", tenantHandle)
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error listing members of tenant %s: %v", tenantHandle, decodeResponse(r))
+	}
+
+	roles := map[string]string{}
+	invited := []string{}
+	for _, member := range members {
+		roles[member.Email] = member.Role
+		if member.Status == "invited" {
+			invited = append(invited, member.Email)
+		}
+		d.Set("tenant_id", member.TenantId)
+	}
+	d.Set("members", roles)
+	d.Set("invited_members", invited)
+
+	return diags
+}
+
+// Invite the missing members, update the roles which changed and remove the members which are not in the configuration
+func resourceTenantMembersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	tenantHandle := d.Get("tenant_handle").(string)
+	members, r, err := listTenantMembers(ctx, client, tenantHandle)
+	if err != nil {
+		return diag.Errorf("error listing members of tenant %s: %v", tenantHandle, decodeResponse(r))
+	}
+	// Tenant members are keyed by email, but updated and removed by user id
+	current := map[string]string{}
+	userIds := map[string]string{}
+	invited := map[string]bool{}
+	for _, member := range members {
+		current[member.Email] = member.Role
+		userIds[member.Email] = member.UserId
+		invited[member.Email] = member.Status == "invited"
+	}
+	desired := membersFromConfig(d)
+
+	// New members are invited, so they are pending until they accept their invitation
+	pending := func(email string) bool {
+		if _, ok := current[email]; !ok {
+			return true
+		}
+		return invited[email]
+	}
+	add, update, remove, err := membersChanges(current, desired, pending)
+	if err != nil {
+		return diag.Errorf("error updating members of tenant %s: %v", tenantHandle, err)
+	}
+
+	for _, email := range add {
+		req := pipes.InviteTenantUserRequest{Email: email, Role: desired[email]}
+		_, r, err = client.APIClient.TenantMembers.Invite(ctx, tenantHandle).Request(req).Execute()
+		if err != nil {
+			return diag.Errorf("error inviting member %s to tenant %s: %s", email, tenantHandle, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Member invited: %s/%s", tenantHandle, email)
+	}
+	for _, email := range update {
+		role := desired[email]
+		req := pipes.UpdateTenantUserRequest{Role: &role}
+		_, r, err = client.APIClient.TenantMembers.Update(ctx, tenantHandle, userIds[email]).Request(req).Execute()
+		if err != nil {
+			return diag.Errorf("error updating membership %s/%s: %s", tenantHandle, email, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Membership updated: %s/%s", tenantHandle, email)
+	}
+	for _, email := range remove {
+		_, r, err = client.APIClient.TenantMembers.Delete(ctx, tenantHandle, userIds[email]).Execute()
+		if err != nil {
+			return diag.Errorf("error removing membership %s/%s: %s", tenantHandle, email, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Membership removed: %s/%s", tenantHandle, email)
+	}
+
+	d.SetId(tenantHandle)
+
+	return resourceTenantMembersRead(ctx, d, meta)
+}
+
+// The members are left as-is, including those added by the resource, since removing all of them would remove the last owner of the tenant
+func resourceTenantMembersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+var tenantMembersIdFormat = newResourceIdFormat("<tenant-handle>")
+
+func resourceTenantMembersImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := resolveResourceId(ctx, meta.(*PipesClient), tenantMembersIdFormat, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("tenant_handle", id["tenant-handle"])
+	return []*schema.ResourceData{d}, nil
+}
+
+func listTenantMembers(ctx context.Context, client *PipesClient, tenantHandle string) ([]pipes.TenantUser, *http.Response, error) {
+	var members []pipes.TenantUser
+	var nextToken string
+	for {
+		req := client.APIClient.TenantMembers.List(ctx, tenantHandle)
+		if nextToken != "" {
+			req = req.NextToken(nextToken)
+		}
+		resp, r, err := req.Execute()
+		if err != nil {
+			return nil, r, err
+		}
+		members = append(members, resp.GetItems()...)
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			return members, r, nil
+		}
+		nextToken = *resp.NextToken
+	}
+}
@@ -0,0 +1,90 @@
+package pipes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// test suites
+// To run this test :
+// 1. Enter a valid custom tenant handle which has already been created
+// 2. Enter the email address of an owner of the tenant, e.g. the user running the test
+// 3. Enter a valid email address for a member that you are trying to invite to the tenant
+func TestAccTenantMembers_Basic(t *testing.T) {
+	resourceName := "pipes_tenant_members.test"
+	tenantHandle := "[insert_tenant_handle_here]"
+	ownerEmail := "[insert_owner_email_here]"
+	memberEmail := "user@domain.com"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTenantMembersConfig(tenantHandle, ownerEmail, memberEmail, "member"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTenantExists(tenantHandle),
+					resource.TestCheckResourceAttr(resourceName, "members.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("members.%s", memberEmail), "member"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "invited_members.*", memberEmail),
+					resource.TestCheckResourceAttrSet(resourceName, "tenant_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccTenantMembersConfig(tenantHandle, ownerEmail, memberEmail, "owner"),
+				Check:  resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("members.%s", memberEmail), "owner"),
+			},
+			{
+				// the invited member has not accepted the invitation, so the active owner cannot be demoted
+				Config:      testAccTenantMembersConfig(tenantHandle, ownerEmail, memberEmail, "owner", "member"),
+				ExpectError: regexp.MustCompile("refusing to demote or remove the last active owner"),
+			},
+			{
+				Config:      testAccTenantMembersNoOwnerConfig(tenantHandle, ownerEmail),
+				ExpectError: regexp.MustCompile("at least one member must have the owner role"),
+			},
+		},
+	})
+}
+
+// configs
+func testAccTenantMembersConfig(tenantHandle, ownerEmail, memberEmail, memberRole string, ownerRole ...string) string {
+	role := "owner"
+	if len(ownerRole) > 0 {
+		role = ownerRole[0]
+	}
+	return fmt.Sprintf(`
+data "pipes_tenant" "test_tenant" {
+	handle = "%s"
+}
+
+# Please provide valid emails
+resource "pipes_tenant_members" "test" {
+	tenant_handle = data.pipes_tenant.test_tenant.handle
+	members = {
+		"%s" = "%s"
+		"%s" = "%s"
+	}
+}`, tenantHandle, ownerEmail, role, memberEmail, memberRole)
+}
+
+func testAccTenantMembersNoOwnerConfig(tenantHandle, ownerEmail string) string {
+	return fmt.Sprintf(`
+data "pipes_tenant" "test_tenant" {
+	handle = "%s"
+}
+
+resource "pipes_tenant_members" "test" {
+	tenant_handle = data.pipes_tenant.test_tenant.handle
+	members = {
+		"%s" = "member"
+	}
+}`, tenantHandle, ownerEmail)
+}
@@ -2,26 +2,25 @@ package pipes
 
 import (
 	"context"
-	"encoding/json"
 	"log"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var tenantNotifierIdFormat = newResourceIdFormat("<notifier-name>")
+
 func resourceTenantNotifier() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantNotifierCreate,
 		ReadContext:   resourceTenantNotifierRead,
 		UpdateContext: resourceTenantNotifierUpdate,
 		DeleteContext: resourceTenantNotifierDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		CustomizeDiff: notifierCustomizeDiff,
+		Importer:      resourceIdImporter(tenantNotifierIdFormat, resolveTenantNotifierId),
 		Schema: map[string]*schema.Schema{
 			"notifier_id": {
 				Type:     schema.TypeString,
@@ -35,11 +34,10 @@ func resourceTenantNotifier() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"notifies": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsJSON,
-			},
+			"notifies":            notifiesSchema(),
+			"notify":              notifySchema(),
+			"notifies_wo":         notifiesWriteOnlySchema(),
+			"notifies_wo_version": notifiesWriteOnlyVersionSchema(),
 			"precedence": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -94,13 +92,9 @@ func resourceTenantNotifierCreate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	notifierName := d.Get("name").(string)
@@ -123,7 +117,7 @@ func resourceTenantNotifierCreate(ctx context.Context, d *schema.ResourceData, m
 	d.Set("notifier_id", resp.Id)
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("name", resp.Name)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -148,7 +142,11 @@ func resourceTenantNotifierRead(ctx context.Context, d *schema.ResourceData, met
 	var err error
 	var resp pipes.Notifier
 
-	notifierName := d.Id()
+	id, err := tenantNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	notifierName := id["notifier-name"]
 
 	client := meta.(*PipesClient)
 
@@ -161,7 +159,7 @@ func resourceTenantNotifierRead(ctx context.Context, d *schema.ResourceData, met
 	d.Set("notifier_id", resp.Id)
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("name", resp.Name)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -196,13 +194,9 @@ func resourceTenantNotifierUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	// create request
@@ -224,7 +218,7 @@ func resourceTenantNotifierUpdate(ctx context.Context, d *schema.ResourceData, m
 	d.Set("notifier_id", resp.Id)
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("name", resp.Name)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -249,7 +243,11 @@ func resourceTenantNotifierDelete(ctx context.Context, d *schema.ResourceData, m
 	var err error
 
 	client := meta.(*PipesClient)
-	notifierName := d.Id()
+	id, err := tenantNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	notifierName := id["notifier-name"]
 
 	_, r, err = client.APIClient.TenantNotifiers.Delete(ctx, notifierName).Execute()
 	if err != nil {
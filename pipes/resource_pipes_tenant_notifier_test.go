@@ -29,6 +29,12 @@ func TestAccTenantNotifier_Basic(t *testing.T) {
 				Config: testAccTenantNotifierUpdateConfig(emailIntegrationHandle, notifierNameUpdated),
 				Check:  resource.TestCheckResourceAttr("pipes_tenant_notifier.email", "name", notifierNameUpdated),
 			},
+			{
+				ResourceName:      "pipes_tenant_notifier.email",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFromAttribute("pipes_tenant_notifier.email", "notifier_id"),
+				ImportStateCheck:  testAccCheckImportedId(notifierNameUpdated),
+			},
 		},
 	})
 }
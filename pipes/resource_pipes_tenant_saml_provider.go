@@ -0,0 +1,406 @@
+package pipes
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+// A warning is raised when the certificate of the IdP expires within this period
+const samlCertificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// SAML bindings of the IdP SSO service, in order of preference
+var samlSsoBindings = []string{
+	"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect",
+	"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST",
+}
+
+var tenantSamlProviderIdFormat = newResourceIdFormat("tenant/saml")
+
+func resourceTenantSamlProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTenantSamlProviderUpdate,
+		ReadContext:   resourceTenantSamlProviderRead,
+		UpdateContext: resourceTenantSamlProviderUpdate,
+		DeleteContext: resourceTenantSamlProviderDelete,
+		Importer:      resourceIdImporter(tenantSamlProviderIdFormat),
+		CustomizeDiff: tenantSamlProviderCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "enabled",
+				ValidateFunc: validation.StringInSlice([]string{"enabled", "disabled"}, false),
+			},
+
+			// The IdP is configured either with its certificate, issuer and SSO URL or with its metadata document
+			"certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Sensitive:        true,
+				ExactlyOneOf:     []string{"certificate", "idp_metadata_xml"},
+				RequiredWith:     []string{"certificate", "issuer", "sso_url"},
+				ValidateFunc:     validateSamlCertificate,
+				DiffSuppressFunc: samlCertificatesEqual,
+			},
+			"issuer": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				RequiredWith:  []string{"certificate", "issuer", "sso_url"},
+				ConflictsWith: []string{"idp_metadata_xml"},
+				ValidateFunc:  validation.StringIsNotWhiteSpace,
+			},
+			"sso_url": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				RequiredWith:  []string{"certificate", "issuer", "sso_url"},
+				ConflictsWith: []string{"idp_metadata_xml"},
+				ValidateFunc:  validation.IsURLWithHTTPS,
+			},
+			"idp_metadata_xml": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"certificate", "idp_metadata_xml"},
+				ValidateFunc: validateSamlIdpMetadata,
+			},
+
+			// Details of the IdP certificate
+			"certificate_expires_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Metadata
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceTenantSamlProviderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	resp, r, err := client.APIClient.Tenants.GetSettings(ctx).Execute()
+	if err != nil {
+		return diag.Errorf("error reading SAML provider of tenant: %v", decodeResponse(r))
+	}
+
+	return resourceTenantSamlProviderPopulateFromResponse(d, resp)
+}
+
+func resourceTenantSamlProviderUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	current, r, err := client.APIClient.Tenants.GetSettings(ctx).Execute()
+	if err != nil {
+		return diag.Errorf("error reading SAML provider of tenant: %v", decodeResponse(r))
+	}
+
+	// The certificate, issuer and SSO URL are derived from the metadata document at plan time when it is set
+	state := d.Get("state").(string)
+	certificate := d.Get("certificate").(string)
+	issuer := d.Get("issuer").(string)
+	ssoUrl := d.Get("sso_url").(string)
+
+	resp := current
+	if current.LoginSaml.State != state ||
+		!samlCertificatesEqual("", current.LoginSaml.GetCertificate(), certificate, nil) ||
+		current.LoginSaml.GetIssuer() != issuer ||
+		current.LoginSaml.GetSsoUrl() != ssoUrl {
+		req := pipes.UpdateTenantSettingsRequest{
+			LoginSaml: &pipes.UpdateTenantSamlLoginSettings{
+				State:       state,
+				Certificate: &certificate,
+				Issuer:      &issuer,
+				SsoUrl:      &ssoUrl,
+			},
+		}
+		resp, r, err = client.APIClient.Tenants.UpdateSettings(ctx).Request(req).Execute()
+		if err != nil {
+			return diag.Errorf("error updating SAML provider of tenant: %v", decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] SAML provider updated for tenant, issuer: %s", issuer)
+	}
+
+	return resourceTenantSamlProviderPopulateFromResponse(d, resp)
+}
+
+// Removing the SAML provider disables SAML login for the tenant, the IdP details are left as-is
+func resourceTenantSamlProviderDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	req := pipes.UpdateTenantSettingsRequest{
+		LoginSaml: &pipes.UpdateTenantSamlLoginSettings{State: "disabled"},
+	}
+	_, r, err := client.APIClient.Tenants.UpdateSettings(ctx).Request(req).Execute()
+	if err != nil {
+		return diag.Errorf("error disabling SAML provider of tenant: %v", decodeResponse(r))
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// tenantSamlProviderCustomizeDiff:: Derive the IdP details from the metadata document when it is set, and the expiry from the certificate
+func tenantSamlProviderCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// The IdP details cannot be derived until the metadata document or certificate is known
+	if !d.NewValueKnown("idp_metadata_xml") {
+		for _, key := range []string{"certificate", "issuer", "sso_url", "certificate_expires_at"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !d.NewValueKnown("certificate") {
+		return d.SetNewComputed("certificate_expires_at")
+	}
+
+	certificate := d.Get("certificate").(string)
+	if metadata, ok := d.GetOk("idp_metadata_xml"); ok {
+		idp, err := parseSamlIdpMetadata(metadata.(string))
+		if err != nil {
+			return fmt.Errorf("idp_metadata_xml: %v", err)
+		}
+		for key, value := range map[string]string{"certificate": idp.Certificate, "issuer": idp.Issuer, "sso_url": idp.SsoUrl} {
+			if old, _ := d.GetChange(key); key == "certificate" && samlCertificatesEqual(key, old.(string), value, nil) {
+				continue
+			}
+			if err := d.SetNew(key, value); err != nil {
+				return err
+			}
+		}
+		certificate = idp.Certificate
+	}
+
+	if certificate == "" {
+		return nil
+	}
+	cert, err := parseSamlCertificate(certificate)
+	if err != nil {
+		return fmt.Errorf("certificate: %v", err)
+	}
+	return d.SetNew("certificate_expires_at", cert.NotAfter.UTC().Format(time.RFC3339))
+}
+
+func resourceTenantSamlProviderPopulateFromResponse(d *schema.ResourceData, resp pipes.TenantSettings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	d.Set("state", resp.LoginSaml.State)
+	d.Set("issuer", resp.LoginSaml.GetIssuer())
+	d.Set("sso_url", resp.LoginSaml.GetSsoUrl())
+
+	// Keep the certificate as configured when the API returns it with different formatting
+	certificate := resp.LoginSaml.GetCertificate()
+	if !samlCertificatesEqual("certificate", d.Get("certificate").(string), certificate, nil) {
+		d.Set("certificate", certificate)
+	}
+	if certificate != "" {
+		if cert, err := parseSamlCertificate(certificate); err == nil {
+			d.Set("certificate_expires_at", cert.NotAfter.UTC().Format(time.RFC3339))
+			if warning := samlCertificateExpiryWarning(cert); warning != "" {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  warning,
+				})
+			}
+		}
+	}
+
+	// metadata
+	if resp.UpdatedAt != nil {
+		d.Set("updated_at", *resp.UpdatedAt)
+	}
+	if resp.UpdatedBy != nil {
+		d.Set("updated_by", resp.UpdatedBy.Handle)
+	}
+	d.Set("version_id", resp.VersionId)
+
+	d.SetId("tenant/saml")
+
+	return diags
+}
+
+// samlIdpMetadata:: The details of an IdP derived from its SAML metadata document
+type samlIdpMetadata struct {
+	Issuer      string
+	SsoUrl      string
+	Certificate string
+}
+
+type samlEntityDescriptor struct {
+	XMLName          xml.Name
+	EntityID         string                 `xml:"entityID,attr"`
+	IDPSSODescriptor *samlIDPSSODescriptor  `xml:"IDPSSODescriptor"`
+	Entities         []samlEntityDescriptor `xml:"EntityDescriptor"`
+}
+
+type samlIDPSSODescriptor struct {
+	KeyDescriptors []struct {
+		Use              string   `xml:"use,attr"`
+		X509Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+	} `xml:"KeyDescriptor"`
+	SingleSignOnServices []struct {
+		Binding  string `xml:"Binding,attr"`
+		Location string `xml:"Location,attr"`
+	} `xml:"SingleSignOnService"`
+}
+
+// parseSamlIdpMetadata:: Derive the issuer, SSO URL and signing certificate of an IdP from its metadata document,
+// which is either an EntityDescriptor or an EntitiesDescriptor holding a single IdP
+func parseSamlIdpMetadata(metadata string) (samlIdpMetadata, error) {
+	var idp samlIdpMetadata
+
+	var entity samlEntityDescriptor
+	if err := xml.Unmarshal([]byte(metadata), &entity); err != nil {
+		return idp, fmt.Errorf("invalid IdP metadata document: %v", err)
+	}
+	if entity.XMLName.Local == "EntitiesDescriptor" {
+		var idps []samlEntityDescriptor
+		for _, e := range entity.Entities {
+			if e.IDPSSODescriptor != nil {
+				idps = append(idps, e)
+			}
+		}
+		if len(idps) != 1 {
+			return idp, fmt.Errorf("expected exactly one IdP in the metadata document, found %d", len(idps))
+		}
+		entity = idps[0]
+	} else if entity.XMLName.Local != "EntityDescriptor" {
+		return idp, fmt.Errorf("unexpected root element %q in IdP metadata document, expected EntityDescriptor", entity.XMLName.Local)
+	}
+	if entity.IDPSSODescriptor == nil {
+		return idp, fmt.Errorf("no IDPSSODescriptor found in IdP metadata document")
+	}
+	if strings.TrimSpace(entity.EntityID) == "" {
+		return idp, fmt.Errorf("no entityID found in IdP metadata document")
+	}
+	idp.Issuer = strings.TrimSpace(entity.EntityID)
+
+	// Pick the SSO service with the most preferred binding
+	for _, binding := range samlSsoBindings {
+		for _, service := range entity.IDPSSODescriptor.SingleSignOnServices {
+			if service.Binding == binding && idp.SsoUrl == "" {
+				idp.SsoUrl = strings.TrimSpace(service.Location)
+			}
+		}
+	}
+	if idp.SsoUrl == "" {
+		return idp, fmt.Errorf("no SingleSignOnService with an HTTP-Redirect or HTTP-POST binding found in IdP metadata document")
+	}
+
+	// Pick the first signing certificate, a key without a use being valid for signing
+	for _, key := range entity.IDPSSODescriptor.KeyDescriptors {
+		if (key.Use == "" || key.Use == "signing") && len(key.X509Certificates) > 0 && idp.Certificate == "" {
+			der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(key.X509Certificates[0]), ""))
+			if err != nil {
+				return idp, fmt.Errorf("invalid X509Certificate in IdP metadata document: %v", err)
+			}
+			idp.Certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+		}
+	}
+	if idp.Certificate == "" {
+		return idp, fmt.Errorf("no signing certificate found in IdP metadata document")
+	}
+	if _, err := parseSamlCertificate(idp.Certificate); err != nil {
+		return idp, err
+	}
+
+	return idp, nil
+}
+
+// parseSamlCertificate:: Parse an X.509 certificate in PEM format
+func parseSamlCertificate(certificate string) (*x509.Certificate, error) {
+	block, rest := pem.Decode([]byte(strings.TrimSpace(certificate)))
+	if block == nil {
+		return nil, fmt.Errorf("expected an X.509 certificate in PEM format")
+	}
+	if block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("expected a PEM block of type CERTIFICATE, got %q", block.Type)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("expected a single X.509 certificate, got trailing data after the first PEM block")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid X.509 certificate: %v", err)
+	}
+	return cert, nil
+}
+
+// samlCertificateExpiryWarning:: Warn when a certificate has expired or is about to expire
+func samlCertificateExpiryWarning(cert *x509.Certificate) string {
+	remaining := time.Until(cert.NotAfter)
+	if remaining <= 0 {
+		return fmt.Sprintf("the certificate of the SAML IdP expired at %s, users will not be able to sign in with SAML until it is replaced", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if remaining < samlCertificateExpiryWarningPeriod {
+		return fmt.Sprintf("the certificate of the SAML IdP expires at %s, in %d days", cert.NotAfter.UTC().Format(time.RFC3339), int(remaining.Hours()/24))
+	}
+	return ""
+}
+
+func validateSamlCertificate(v interface{}, k string) (warnings []string, errs []error) {
+	cert, err := parseSamlCertificate(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %v", k, err)}
+	}
+	if warning := samlCertificateExpiryWarning(cert); warning != "" {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", k, warning))
+	}
+	return warnings, nil
+}
+
+func validateSamlIdpMetadata(v interface{}, k string) (warnings []string, errs []error) {
+	idp, err := parseSamlIdpMetadata(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %v", k, err)}
+	}
+	cert, _ := parseSamlCertificate(idp.Certificate)
+	if warning := samlCertificateExpiryWarning(cert); warning != "" {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", k, warning))
+	}
+	return warnings, nil
+}
+
+// samlCertificatesEqual:: Certificates are equal when they hold the same DER bytes, regardless of PEM formatting
+func samlCertificatesEqual(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	oldCert, err := parseSamlCertificate(old)
+	if err != nil {
+		return false
+	}
+	newCert, err := parseSamlCertificate(new)
+	if err != nil {
+		return false
+	}
+	return oldCert.Equal(newCert)
+}
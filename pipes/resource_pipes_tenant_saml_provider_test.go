@@ -0,0 +1,271 @@
+package pipes
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTenantSamlProvider_Basic(t *testing.T) {
+	resourceName := "pipes_tenant_saml_provider.test"
+	certificate := testSamlCertificate(t, time.Now().AddDate(1, 0, 0))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTenantSamlProviderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTenantSamlProviderConfig(certificate, "https://idp.example.com/metadata"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "state", "disabled"),
+					resource.TestCheckResourceAttr(resourceName, "issuer", "https://idp.example.com/metadata"),
+					resource.TestCheckResourceAttr(resourceName, "sso_url", "https://idp.example.com/sso"),
+					resource.TestCheckResourceAttrSet(resourceName, "certificate_expires_at"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"certificate"},
+			},
+			{
+				Config: testAccTenantSamlProviderConfig(certificate, "https://idp.example.com/updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "issuer", "https://idp.example.com/updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseSamlCertificate(t *testing.T) {
+	valid := testSamlCertificate(t, time.Now().AddDate(1, 0, 0))
+	expired := testSamlCertificate(t, time.Now().AddDate(0, 0, -1))
+	block, _ := pem.Decode([]byte(valid))
+	base64Only := base64.StdEncoding.EncodeToString(block.Bytes)
+
+	cases := map[string]struct {
+		certificate string
+		expected    string
+	}{
+		"valid":                 {certificate: valid},
+		"valid with whitespace": {certificate: "\n  " + valid + "\n"},
+		"expired":               {certificate: expired},
+		"base64 without PEM":    {certificate: base64Only, expected: "expected an X.509 certificate in PEM format"},
+		"empty":                 {certificate: "", expected: "expected an X.509 certificate in PEM format"},
+		"malformed":             {certificate: "-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----", expected: "invalid X.509 certificate"},
+		"wrong block type":      {certificate: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: block.Bytes})), expected: "expected a PEM block of type CERTIFICATE"},
+		"several certificates":  {certificate: valid + expired, expected: "expected a single X.509 certificate"},
+	}
+	for name, c := range cases {
+		cert, err := parseSamlCertificate(c.certificate)
+		switch {
+		case c.expected == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", name, err)
+		case c.expected == "" && cert == nil:
+			t.Errorf("%s: expected a certificate", name)
+		case c.expected != "" && (err == nil || !strings.Contains(err.Error(), c.expected)):
+			t.Errorf("%s: expected an error containing %q, got %v", name, c.expected, err)
+		}
+	}
+}
+
+func TestSamlCertificateExpiryWarning(t *testing.T) {
+	cases := map[string]struct {
+		notAfter time.Time
+		expected string
+	}{
+		"valid":         {notAfter: time.Now().AddDate(1, 0, 0)},
+		"expiring soon": {notAfter: time.Now().AddDate(0, 0, 10), expected: "the certificate of the SAML IdP expires at"},
+		"expired":       {notAfter: time.Now().AddDate(0, 0, -1), expected: "the certificate of the SAML IdP expired at"},
+	}
+	for name, c := range cases {
+		cert, err := parseSamlCertificate(testSamlCertificate(t, c.notAfter))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		warning := samlCertificateExpiryWarning(cert)
+		switch {
+		case c.expected == "" && warning != "":
+			t.Errorf("%s: unexpected warning %q", name, warning)
+		case c.expected != "" && !strings.HasPrefix(warning, c.expected):
+			t.Errorf("%s: expected a warning starting with %q, got %q", name, c.expected, warning)
+		}
+	}
+
+	// the warning of an expired certificate is raised at plan time, without failing the validation
+	warnings, errs := validateSamlCertificate(testSamlCertificate(t, time.Now().AddDate(0, 0, -1)), "certificate")
+	if len(errs) > 0 || len(warnings) != 1 {
+		t.Errorf("expected a single warning for an expired certificate, got warnings %v and errors %v", warnings, errs)
+	}
+}
+
+func TestSamlCertificatesEqual(t *testing.T) {
+	certificate := testSamlCertificate(t, time.Now().AddDate(1, 0, 0))
+	other := testSamlCertificate(t, time.Now().AddDate(1, 0, 0))
+	block, _ := pem.Decode([]byte(certificate))
+	// the same certificate with base64 lines of a different length and CRLF line endings
+	body := base64.StdEncoding.EncodeToString(block.Bytes)
+	var lines []string
+	for len(body) > 40 {
+		lines = append(lines, body[:40])
+		body = body[40:]
+	}
+	lines = append(lines, body)
+	reformatted := "-----BEGIN CERTIFICATE-----\r\n" + strings.Join(lines, "\r\n") + "\r\n-----END CERTIFICATE-----\r\n"
+
+	cases := map[string]struct {
+		old, new string
+		expected bool
+	}{
+		"identical":   {old: certificate, new: certificate, expected: true},
+		"reformatted": {old: certificate, new: reformatted, expected: true},
+		"different":   {old: certificate, new: other, expected: false},
+		"unset":       {old: "", new: certificate, expected: false},
+		"malformed":   {old: certificate, new: "not a certificate", expected: false},
+	}
+	for name, c := range cases {
+		if got := samlCertificatesEqual("certificate", c.old, c.new, nil); got != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, got)
+		}
+	}
+}
+
+func TestParseSamlIdpMetadata(t *testing.T) {
+	certificate := testSamlCertificate(t, time.Now().AddDate(1, 0, 0))
+	block, _ := pem.Decode([]byte(certificate))
+	encoded := base64.StdEncoding.EncodeToString(block.Bytes)
+	// the certificates of metadata documents are usually wrapped and indented
+	wrapped := "\n        " + encoded[:64] + "\n        " + encoded[64:] + "\n      "
+
+	entity := func(keys, services string) string {
+		return fmt.Sprintf(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">%s%s
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`, keys, services)
+	}
+	key := func(use, cert string) string {
+		attr := ""
+		if use != "" {
+			attr = fmt.Sprintf(` use="%s"`, use)
+		}
+		return fmt.Sprintf(`
+    <md:KeyDescriptor%s><ds:KeyInfo><ds:X509Data><ds:X509Certificate>%s</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>`, attr, cert)
+	}
+	service := func(binding, location string) string {
+		return fmt.Sprintf(`
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:%s" Location="%s"/>`, binding, location)
+	}
+	redirect := service("HTTP-Redirect", "https://idp.example.com/sso/redirect")
+	post := service("HTTP-POST", "https://idp.example.com/sso/post")
+
+	cases := map[string]struct {
+		metadata       string
+		expectedSsoUrl string
+		expected       string
+	}{
+		"signing key":              {metadata: entity(key("signing", encoded), post+redirect), expectedSsoUrl: "https://idp.example.com/sso/redirect"},
+		"key without use":          {metadata: entity(key("", wrapped), post), expectedSsoUrl: "https://idp.example.com/sso/post"},
+		"encryption key first":     {metadata: entity(key("encryption", "bm90IGEgY2VydGlmaWNhdGU=")+key("signing", encoded), redirect), expectedSsoUrl: "https://idp.example.com/sso/redirect"},
+		"entities descriptor":      {metadata: `<EntitiesDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata">` + entity(key("signing", encoded), redirect) + `</EntitiesDescriptor>`, expectedSsoUrl: "https://idp.example.com/sso/redirect"},
+		"no signing key":           {metadata: entity(key("encryption", encoded), redirect), expected: "no signing certificate found"},
+		"no key":                   {metadata: entity("", redirect), expected: "no signing certificate found"},
+		"malformed certificate":    {metadata: entity(key("signing", "not base64!"), redirect), expected: "invalid X509Certificate"},
+		"no supported SSO binding": {metadata: entity(key("signing", encoded), service("SOAP", "https://idp.example.com/sso/soap")), expected: "no SingleSignOnService"},
+		"no IdP":                   {metadata: `<EntityDescriptor xmlns="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com"/>`, expected: "no IDPSSODescriptor found"},
+		"several IdPs":             {metadata: `<EntitiesDescriptor>` + entity(key("signing", encoded), redirect) + entity(key("signing", encoded), redirect) + `</EntitiesDescriptor>`, expected: "expected exactly one IdP"},
+		"unexpected root element":  {metadata: `<Metadata/>`, expected: "unexpected root element"},
+		"malformed XML":            {metadata: `<EntityDescriptor`, expected: "invalid IdP metadata document"},
+	}
+	for name, c := range cases {
+		idp, err := parseSamlIdpMetadata(c.metadata)
+		if c.expected != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expected) {
+				t.Errorf("%s: expected an error containing %q, got %v", name, c.expected, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if idp.Issuer != "https://idp.example.com/metadata" {
+			t.Errorf("%s: unexpected issuer %q", name, idp.Issuer)
+		}
+		if idp.SsoUrl != c.expectedSsoUrl {
+			t.Errorf("%s: expected SSO URL %q, got %q", name, c.expectedSsoUrl, idp.SsoUrl)
+		}
+		if !samlCertificatesEqual("certificate", certificate, idp.Certificate, nil) {
+			t.Errorf("%s: unexpected certificate %q", name, idp.Certificate)
+		}
+	}
+}
+
+// configs
+func testAccTenantSamlProviderConfig(certificate, issuer string) string {
+	return fmt.Sprintf(`
+resource "pipes_tenant_saml_provider" "test" {
+	state       = "disabled"
+	certificate = <<-EOT
+%s
+	EOT
+	issuer      = "%s"
+	sso_url     = "https://idp.example.com/sso"
+}`, strings.TrimSpace(certificate), issuer)
+}
+
+// helper functions
+func testAccCheckTenantSamlProviderDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*PipesClient)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type == "pipes_tenant_saml_provider" {
+			resp, _, err := client.APIClient.Tenants.GetSettings(context.Background()).Execute()
+			if err != nil {
+				return fmt.Errorf("error fetching settings of tenant. %s", err)
+			}
+			if resp.LoginSaml.State != "disabled" {
+				return fmt.Errorf("SAML provider of tenant is still %s", resp.LoginSaml.State)
+			}
+		}
+	}
+	return nil
+}
+
+// testSamlCertificate:: A self-signed certificate in PEM format expiring at the given time
+func testSamlCertificate(t *testing.T, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("rand.Int: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    notAfter.AddDate(-2, 0, 0),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -10,15 +10,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var tenantServiceAccountIdFormat = newResourceIdFormat("<service-account-id>")
+
 func resourceTenantServiceAccount() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantServiceAccountCreate,
 		ReadContext:   resourceTenantServiceAccountRead,
 		UpdateContext: resourceTenantServiceAccountUpdate,
 		DeleteContext: resourceTenantServiceAccountDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(tenantServiceAccountIdFormat),
 		Schema: map[string]*schema.Schema{
 			"service_account_id": {
 				Type:     schema.TypeString,
@@ -86,7 +86,11 @@ func resourceTenantServiceAccountRead(ctx context.Context, d *schema.ResourceDat
 	client := meta.(*PipesClient)
 	var diags diag.Diagnostics
 
-	id := d.Id()
+	parts, err := tenantServiceAccountIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := parts["service-account-id"]
 
 	resp, r, err := client.APIClient.TenantServiceAccounts.Get(ctx, id).Execute()
 	if err != nil {
@@ -107,7 +111,11 @@ func resourceTenantServiceAccountUpdate(ctx context.Context, d *schema.ResourceD
 	client := meta.(*PipesClient)
 	var diags diag.Diagnostics
 
-	id := d.Id()
+	parts, err := tenantServiceAccountIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := parts["service-account-id"]
 
 	req := pipes.UpdateServiceAccountUserRequest{}
 	var hasUpdate bool
@@ -136,7 +144,11 @@ func resourceTenantServiceAccountDelete(ctx context.Context, d *schema.ResourceD
 	client := meta.(*PipesClient)
 	var diags diag.Diagnostics
 
-	id := d.Id()
+	parts, err := tenantServiceAccountIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id := parts["service-account-id"]
 	r, err := client.APIClient.TenantServiceAccounts.Delete(ctx, id).Execute()
 	if err != nil {
 		return diag.Errorf("resourceTenantServiceAccountDelete. Delete tenant service account error: %v", decodeResponse(r))
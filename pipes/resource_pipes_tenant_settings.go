@@ -11,15 +11,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var tenantSettingsIdFormat = newResourceIdFormat("tenant/settings")
+
 func resourceTenantSettings() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceTenantSettingsUpdate,
 		ReadContext:   resourceTenantSettingsRead,
 		UpdateContext: resourceTenantSettingsUpdate,
 		DeleteContext: resourceTenantSettingsDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(tenantSettingsIdFormat),
 		Schema: map[string]*schema.Schema{
 			// Timeouts are in seconds as per UpdateTenantSettingsRequest
 			"cli_session_timeout": {
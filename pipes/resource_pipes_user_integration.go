@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,15 +12,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var userIntegrationIdFormat = newResourceIdFormat("<user-handle>/<integration-handle>")
+
 func resourceUserIntegration() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceUserIntegrationCreate,
 		ReadContext:   resourceUserIntegrationRead,
 		UpdateContext: resourceUserIntegrationUpdate,
 		DeleteContext: resourceUserIntegrationDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(userIntegrationIdFormat),
 		Schema: map[string]*schema.Schema{
 			"integration_id": {
 				Type:     schema.TypeString,
@@ -202,20 +201,14 @@ func resourceUserIntegrationRead(ctx context.Context, d *schema.ResourceData, me
 	client := meta.(*PipesClient)
 
 	// Warning or errors can be collected in a slice type
-	var integrationHandle, userHandle string
 	var diags diag.Diagnostics
-	id := d.Id()
 
 	// Id is fof format "UserHandle/IntegrationHandle"
-	ids := strings.Split(id, "/")
-	if len(ids) == 2 {
-		userHandle = ids[0]
-		integrationHandle = ids[1]
-	}
-
-	if integrationHandle == "" {
-		return diag.Errorf("resourceUserIntegrationRead. Integration details is not present.")
+	id, err := userIntegrationIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	userHandle, integrationHandle := id["user-handle"], id["integration-handle"]
 
 	resp, r, err := client.APIClient.UserIntegrations.Get(context.Background(), userHandle, integrationHandle).Execute()
 	if err != nil {
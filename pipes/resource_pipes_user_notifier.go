@@ -2,39 +2,36 @@ package pipes
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/turbot/go-kit/types"
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var userNotifierIdFormat = newResourceIdFormat("<user-handle>/<notifier-name>")
+
 func resourceUserNotifier() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceUserNotifierCreate,
 		ReadContext:   resourceUserNotifierRead,
 		UpdateContext: resourceUserNotifierUpdate,
 		DeleteContext: resourceUserNotifierDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		CustomizeDiff: notifierCustomizeDiff,
+		Importer:      resourceIdImporter(userNotifierIdFormat, resolveUserNotifierId),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"notifies": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsJSON,
-			},
+			"notifies":            notifiesSchema(),
+			"notify":              notifySchema(),
+			"notifies_wo":         notifiesWriteOnlySchema(),
+			"notifies_wo_version": notifiesWriteOnlyVersionSchema(),
 			"notifier_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -105,13 +102,9 @@ func resourceUserNotifierCreate(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	// create request
@@ -140,7 +133,7 @@ func resourceUserNotifierCreate(ctx context.Context, d *schema.ResourceData, met
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -166,14 +159,11 @@ func resourceUserNotifierRead(ctx context.Context, d *schema.ResourceData, meta
 	var resp pipes.Notifier
 
 	var userHandle, notifierName string
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		userHandle = parts[0]
-		notifierName = parts[1]
-	default:
-		return diag.Errorf("error parsing user handle and notifier name from id: %s", d.Id())
+	id, err := userNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	userHandle, notifierName = id["user-handle"], id["notifier-name"]
 
 	client := meta.(*PipesClient)
 
@@ -188,7 +178,7 @@ func resourceUserNotifierRead(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -214,14 +204,11 @@ func resourceUserNotifierUpdate(ctx context.Context, d *schema.ResourceData, met
 	var resp pipes.Notifier
 
 	var userHandle, notifierName string
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		userHandle = parts[0]
-		notifierName = parts[1]
-	default:
-		return diag.Errorf("error parsing user handle and notifier name from id: %s", d.Id())
+	id, err := userNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	userHandle, notifierName = id["user-handle"], id["notifier-name"]
 
 	s := d.Get("state").(string)
 	state, err := pipes.NewNotifierStateFromValue(s)
@@ -229,13 +216,9 @@ func resourceUserNotifierUpdate(ctx context.Context, d *schema.ResourceData, met
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	newNotifierName := notifierName
@@ -266,7 +249,7 @@ func resourceUserNotifierUpdate(ctx context.Context, d *schema.ResourceData, met
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -293,14 +276,11 @@ func resourceUserNotifierDelete(ctx context.Context, d *schema.ResourceData, met
 	client := meta.(*PipesClient)
 
 	var userHandle, notifierName string
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		userHandle = parts[0]
-		notifierName = parts[1]
-	default:
-		return diag.Errorf("error parsing user handle and notifier name from id: %s", d.Id())
+	id, err := userNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	userHandle, notifierName = id["user-handle"], id["notifier-name"]
 
 	_, r, err = client.APIClient.UserNotifiers.Delete(ctx, userHandle, notifierName).Execute()
 	if err != nil {
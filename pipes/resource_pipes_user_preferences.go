@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,15 +11,15 @@ import (
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var userPreferencesIdFormat = newResourceIdFormat("<user-handle>/preferences")
+
 func resourceUserPreferences() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceUserPreferencesRead,
 		ReadContext:   resourceUserPreferencesRead,
 		UpdateContext: resourceUserPreferencesUpdate,
 		DeleteContext: resourceUserPreferencesDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(userPreferencesIdFormat),
 		Schema: map[string]*schema.Schema{
 			"communication_community_updates": {
 				Type:     schema.TypeString,
@@ -101,13 +100,12 @@ func resourceUserPreferencesUpdate(ctx context.Context, d *schema.ResourceData,
 
 	client := meta.(*PipesClient)
 
-	id := d.Id()
-
-	if len(id) > 0 {
-		ids := strings.Split(id, "/")
-		if len(ids) == 2 {
-			userHandle = ids[0]
+	if d.Id() != "" {
+		id, err := userPreferencesIdFormat.parse(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
 		}
+		userHandle = id["user-handle"]
 	} else {
 		user, r, err := client.APIClient.Actors.Get(context.Background()).Execute()
 		if err != nil {
@@ -156,10 +154,11 @@ func resourceUserPreferencesDelete(ctx context.Context, d *schema.ResourceData,
 
 	client := meta.(*PipesClient)
 
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 2 {
-		userHandle = ids[0]
+	id, err := userPreferencesIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	userHandle = id["user-handle"]
 
 	var req pipes.UpdateUserPreferencesRequest
 	req.CommunicationCommunityUpdates = types.String("enabled")
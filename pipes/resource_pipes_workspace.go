@@ -6,7 +6,6 @@ import (
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,15 +14,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceIdFormat = workspaceResourceIdFormat()
+
 func resourceWorkspace() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceCreate,
 		ReadContext:   resourceWorkspaceRead,
 		UpdateContext: resourceWorkspaceUpdate,
 		DeleteContext: resourceWorkspaceDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceIdFormat),
 		Schema: map[string]*schema.Schema{
 			"handle": {
 				Type:         schema.TypeString,
@@ -152,17 +151,15 @@ func resourceWorkspaceCreate(ctx context.Context, d *schema.ResourceData, meta i
 		req.DbVolumeSizeBytes = &dbVolumeSizeBytes
 	}
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var userHandler string
-		userHandler, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionCreate. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaces.Create(ctx, userHandler).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaces.Create(ctx, orgHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.UserWorkspaces.Create(ctx, userHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.OrgWorkspaces.Create(ctx, orgHandle).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -172,7 +169,7 @@ func resourceWorkspaceCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Set property values
 	d.Set("handle", resp.Handle)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_id", resp.Id)
 	d.Set("workspace_state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -195,11 +192,8 @@ func resourceWorkspaceCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	// If workspace is created inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle" otherwise "WorkspaceHandle"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s", orgHandle, resp.Handle))
-	} else {
-		d.SetId(resp.Handle)
-	}
+	scope.workspace = resp.Handle
+	d.SetId(scope.id())
 
 	return diags
 }
@@ -208,45 +202,27 @@ func resourceWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta int
 	client := meta.(*PipesClient)
 
 	// Warning or errors can be collected in a slice type
-	var orgHandle, workspaceHandle string
-	var isUser = false
-
 	var diags diag.Diagnostics
-	var resp pipes.Workspace
-	var err error
-	var r *http.Response
-
-	id := d.Id()
 
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(id, ":") {
-		separator = ":"
-	}
 	// If workspace exists inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle" otherwise "WorkspaceHandle"
-	ids := strings.Split(id, separator)
-	if len(ids) == 2 {
-		orgHandle = ids[0]
-		workspaceHandle = ids[1]
-	} else if len(ids) == 1 {
-		isUser = true
-		workspaceHandle = ids[0]
-	}
-
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionCreate. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaces.Get(ctx, actorHandle, workspaceHandle).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaces.Get(ctx, orgHandle, workspaceHandle).Execute()
+	id, err := workspaceIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle := scope.workspace
 
+	resp, r, err := workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.UserWorkspaces.Get(ctx, userHandle, workspaceHandle).Execute()
+		},
+		func(orgHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.OrgWorkspaces.Get(ctx, orgHandle, workspaceHandle).Execute()
+		},
+	)
 	if err != nil {
-		if r.StatusCode == 404 {
+		if r != nil && r.StatusCode == 404 {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
 				Summary:  fmt.Sprintf("Workspace (%s) not found", workspaceHandle),
@@ -260,7 +236,7 @@ func resourceWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta int
 	// assign results back into ResourceData
 	d.Set("workspace_id", resp.Id)
 	d.Set("handle", resp.Handle)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_state", resp.State)
 	d.Set("state_reason", resp.StateReason)
 	d.Set("desired_state", resp.DesiredState)
@@ -279,9 +255,8 @@ func resourceWorkspaceRead(ctx context.Context, d *schema.ResourceData, meta int
 	d.Set("host", resp.Host)
 	d.Set("identity_id", resp.IdentityId)
 	d.Set("version_id", resp.VersionId)
-	if separator == ":" {
-		d.SetId(strings.ReplaceAll(id, ":", "/"))
-	}
+	// For backward-compatibility, an id separated by : is rewritten with /
+	d.SetId(scope.id())
 
 	return diags
 }
@@ -322,21 +297,16 @@ func resourceWorkspaceUpdate(ctx context.Context, d *schema.ResourceData, meta i
 
 	log.Printf("\n[DEBUG] Updating Workspace: %s", *req.Handle)
 
-	var resp pipes.Workspace
-	var userHandler string
-	var err error
-	var r *http.Response
-
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		userHandler, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionUpdate. getUserHandler error:	%v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaces.Update(ctx, userHandler, oldHandle.(string)).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaces.Update(ctx, orgHandle, oldHandle.(string)).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "handle")
+	scope.workspace = oldHandle.(string)
+	resp, r, err := workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.UserWorkspaces.Update(ctx, userHandle, scope.workspace).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.OrgWorkspaces.Update(ctx, orgHandle, scope.workspace).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -345,9 +315,8 @@ func resourceWorkspaceUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	log.Printf("\n[DEBUG] Workspace updated: %s", resp.Handle)
 
 	// Update state file
-	d.SetId(resp.Handle)
 	d.Set("handle", resp.Handle)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_id", resp.Id)
 	d.Set("workspace_state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -370,11 +339,8 @@ func resourceWorkspaceUpdate(ctx context.Context, d *schema.ResourceData, meta i
 
 	// If workspace is created inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle" otherwise "WorkspaceHandle"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s", orgHandle, resp.Handle))
-	} else {
-		d.SetId(resp.Handle)
-	}
+	scope.workspace = resp.Handle
+	d.SetId(scope.id())
 
 	return diags
 }
@@ -384,25 +350,15 @@ func resourceWorkspaceDelete(ctx context.Context, d *schema.ResourceData, meta i
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var workspaceHandle string
-	if value, ok := d.GetOk("handle"); ok {
-		workspaceHandle = value.(string)
-	}
-
-	var err error
-	var r *http.Response
-
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionDelete. getUserHandler error: %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspaces.Delete(ctx, actorHandle, workspaceHandle).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspaces.Delete(ctx, orgHandle, workspaceHandle).Execute()
-	}
+	scope := workspaceScopeFromData(d, "handle")
+	_, r, err := workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.UserWorkspaces.Delete(ctx, userHandle, scope.workspace).Execute()
+		},
+		func(orgHandle string) (pipes.Workspace, *http.Response, error) {
+			return client.APIClient.OrgWorkspaces.Delete(ctx, orgHandle, scope.workspace).Execute()
+		},
+	)
 
 	if err != nil {
 		return diag.Errorf("error deleting workspace: %v", decodeResponse(r))
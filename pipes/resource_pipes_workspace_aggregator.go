@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"path"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -14,15 +17,16 @@ import (
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceAggregatorIdFormat = workspaceResourceIdFormat("<aggregator-handle>")
+
 func resourceWorkspaceAggregator() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceAggregatorCreate,
 		ReadContext:   resourceWorkspaceAggregatorRead,
 		UpdateContext: resourceWorkspaceAggregatorUpdate,
 		DeleteContext: resourceWorkspaceAggregatorDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceAggregatorIdFormat),
+		CustomizeDiff: workspaceAggregatorCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"workspace_aggregator_id": {
 				Type:     schema.TypeString,
@@ -48,8 +52,26 @@ func resourceWorkspaceAggregator() *schema.Resource {
 				Required: true,
 			},
 			"connections": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"connections", "connection_folders"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateConnectionPattern,
+				},
+			},
+			"connection_folders": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				AtLeastOneOf: []string{"connections", "connection_folders"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"resolved_connections": {
 				Type:     schema.TypeList,
-				Required: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"created_at": {
@@ -102,10 +124,24 @@ func resourceWorkspaceAggregatorCreate(ctx context.Context, d *schema.ResourceDa
 	workspaceHandle := d.Get("workspace").(string)
 	aggregatorHandle := d.Get("handle").(string)
 	plugin := d.Get("plugin").(string)
-	connections, err := convertToStringArray(d.Get("connections").([]interface{}))
+
+	userHandle := ""
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		userHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("resourceWorkspaceAggregatorCreate.getUserHandler error  %v", decodeResponse(r))
+		}
+	}
+
+	resolved, r, err := getAggregatorConnections(ctx, d, client, isUser, userHandle, orgHandle, workspaceHandle, plugin)
 	if err != nil {
+		if r != nil {
+			return diag.Errorf("resourceWorkspaceAggregatorCreate.connections error  %v", decodeResponse(r))
+		}
 		return diag.Errorf("resourceWorkspaceAggregatorCreate.connections error  %v", err.Error())
 	}
+	connections := aggregatorRequestConnections(d, resolved)
 
 	log.Printf("\n[DEBUG] Workspace Handle: %v", workspaceHandle)
 	log.Printf("\n[DEBUG] Aggregator Handle: %v", aggregatorHandle)
@@ -115,13 +151,7 @@ func resourceWorkspaceAggregatorCreate(ctx context.Context, d *schema.ResourceDa
 	// Create request
 	req := pipes.CreateAggregatorRequest{Handle: aggregatorHandle, Plugin: plugin, Connections: &connections}
 
-	userHandle := ""
-	isUser, orgHandle := isUserConnection(d)
 	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceAggregatorCreate.getUserHandler error  %v", decodeResponse(r))
-		}
 		resp, r, err = client.APIClient.UserWorkspaceAggregators.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
 	} else {
 		resp, r, err = client.APIClient.OrgWorkspaceAggregators.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
@@ -139,7 +169,7 @@ func resourceWorkspaceAggregatorCreate(ctx context.Context, d *schema.ResourceDa
 	d.Set("handle", resp.Handle)
 	d.Set("type", resp.Type)
 	d.Set("plugin", resp.Plugin)
-	d.Set("connections", resp.Connections)
+	setAggregatorConnections(d, resp.Connections, resolved)
 	d.Set("created_at", resp.CreatedAt)
 	d.Set("updated_at", resp.UpdatedAt)
 	if resp.CreatedBy != nil {
@@ -173,23 +203,14 @@ func resourceWorkspaceAggregatorRead(ctx context.Context, d *schema.ResourceData
 
 	// If an aggregator is created for a workspace inside an organization then the ID will be of the
 	// format "OrganizationHandle/WorkspaceHandle/AggregatorHandle" otherwise "WorkspaceHandle/AggregatorHandle".
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<aggregator-handle>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		aggregatorHandle = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		aggregatorHandle = idParts[1]
+	id, err := workspaceAggregatorIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, aggregatorHandle = id["org-handle"], id["workspace-handle"], id["aggregator-handle"]
+	isUser = orgHandle == ""
 
 	var resp pipes.Aggregator
-	var err error
 	var r *http.Response
 
 	userHandle := ""
@@ -215,7 +236,16 @@ func resourceWorkspaceAggregatorRead(ctx context.Context, d *schema.ResourceData
 	d.Set("handle", resp.Handle)
 	d.Set("type", resp.Type)
 	d.Set("plugin", resp.Plugin)
-	d.Set("connections", resp.Connections)
+	actorHandle := orgHandle
+	if isUser {
+		actorHandle = userHandle
+	}
+	if r, err = refreshAggregatorConnections(ctx, d, client, isUser, actorHandle, workspaceHandle, resp); err != nil {
+		if r != nil {
+			return diag.Errorf("error resolving connections of aggregator: %v", decodeResponse(r))
+		}
+		return diag.Errorf("error resolving connections of aggregator: %v", err)
+	}
 	d.Set("created_at", resp.CreatedAt)
 	d.Set("updated_at", resp.UpdatedAt)
 	if resp.CreatedBy != nil {
@@ -254,10 +284,30 @@ func resourceWorkspaceAggregatorUpdate(ctx context.Context, d *schema.ResourceDa
 	if !ok {
 		return diag.Errorf("resourceWorkspaceAggregatorCreate.handle error : invalid value passed for aggregator handle")
 	}
-	connections, err := convertToStringArray(d.Get("connections").([]interface{}))
+
+	userHandle := ""
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		userHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return diag.Errorf("resourceWorkspaceAggregatorUpdate.getUserHandler error  %v", decodeResponse(r))
+		}
+	}
+
+	// Send the connections resolved at plan time when they are known, so that the aggregator matches the plan
+	var resolved []string
+	if plan := d.GetRawPlan(); !plan.IsNull() && plan.GetAttr("resolved_connections").IsWhollyKnown() {
+		resolved, err = convertToStringArray(d.Get("resolved_connections").([]interface{}))
+	} else {
+		resolved, r, err = getAggregatorConnections(ctx, d, client, isUser, userHandle, orgHandle, workspaceHandle, d.Get("plugin").(string))
+	}
 	if err != nil {
-		return diag.Errorf("resourceWorkspaceAggregatorCreate.connections error  %v", err.Error())
+		if r != nil {
+			return diag.Errorf("resourceWorkspaceAggregatorUpdate.connections error  %v", decodeResponse(r))
+		}
+		return diag.Errorf("resourceWorkspaceAggregatorUpdate.connections error  %v", err.Error())
 	}
+	connections := aggregatorRequestConnections(d, resolved)
 
 	log.Printf("\n[DEBUG] Workspace Handle: %v", workspaceHandle)
 	log.Printf("\n[DEBUG] Aggregator Handle: %v", oldAggregatorHandle)
@@ -266,13 +316,7 @@ func resourceWorkspaceAggregatorUpdate(ctx context.Context, d *schema.ResourceDa
 	// Create request
 	req := pipes.UpdateAggregatorRequest{Handle: &newAggregatorHandle, Connections: &connections}
 
-	userHandle := ""
-	isUser, orgHandle := isUserConnection(d)
 	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceAggregatorUpdate.getUserHandler error  %v", decodeResponse(r))
-		}
 		resp, r, err = client.APIClient.UserWorkspaceAggregators.Update(ctx, userHandle, workspaceHandle, oldAggregatorHandle.(string)).Request(req).Execute()
 	} else {
 		resp, r, err = client.APIClient.OrgWorkspaceAggregators.Update(ctx, orgHandle, workspaceHandle, oldAggregatorHandle.(string)).Request(req).Execute()
@@ -290,7 +334,7 @@ func resourceWorkspaceAggregatorUpdate(ctx context.Context, d *schema.ResourceDa
 	d.Set("handle", resp.Handle)
 	d.Set("type", resp.Type)
 	d.Set("plugin", resp.Plugin)
-	d.Set("connections", resp.Connections)
+	setAggregatorConnections(d, resp.Connections, resolved)
 	d.Set("created_at", resp.CreatedAt)
 	d.Set("updated_at", resp.UpdatedAt)
 	if resp.CreatedBy != nil {
@@ -322,24 +366,15 @@ func resourceWorkspaceAggregatorDelete(ctx context.Context, d *schema.ResourceDa
 	var orgHandle, workspaceHandle, aggregatorHandle string
 	var isUser = false
 
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<aggregator-handle>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		aggregatorHandle = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		aggregatorHandle = idParts[1]
+	id, err := workspaceAggregatorIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, aggregatorHandle = id["org-handle"], id["workspace-handle"], id["aggregator-handle"]
+	isUser = orgHandle == ""
 
 	log.Printf("\n[DEBUG] Deleting Aggregator: %s for Workspace: %s", aggregatorHandle, workspaceHandle)
 
-	var err error
 	var r *http.Response
 
 	if isUser {
@@ -360,3 +395,300 @@ func resourceWorkspaceAggregatorDelete(ctx context.Context, d *schema.ResourceDa
 
 	return diags
 }
+
+// workspaceAggregatorCustomizeDiff:: Resolve the connections selected by pattern or folder, so that plan shows connections
+// matching the selectors that were added to or removed from the workspace outside Terraform
+func workspaceAggregatorCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+	for _, key := range []string{"connections", "connection_folders", "workspace", "organization", "plugin"} {
+		if !config.GetAttr(key).IsWhollyKnown() {
+			return d.SetNewComputed("resolved_connections")
+		}
+	}
+
+	entries, err := convertToStringArray(d.Get("connections").([]interface{}))
+	if err != nil {
+		return err
+	}
+	folders, err := convertToStringArray(d.Get("connection_folders").([]interface{}))
+	if err != nil {
+		return err
+	}
+	old, _ := d.GetChange("resolved_connections")
+	oldResolved, err := convertToStringArray(old.([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	// Connections listed by handle are sent as is
+	if !usesConnectionSelectors(entries, folders) {
+		if !sameStrings(oldResolved, entries) {
+			return d.SetNew("resolved_connections", entries)
+		}
+		return nil
+	}
+
+	// The selectors may match connections created in the same apply, so they are only resolved on apply
+	if d.Id() == "" || d.HasChanges("connections", "connection_folders", "workspace", "organization", "plugin") {
+		return d.SetNewComputed("resolved_connections")
+	}
+
+	client := meta.(*PipesClient)
+	var actorHandle string
+	var r *http.Response
+	orgHandle := d.Get("organization").(string)
+	isUser := orgHandle == ""
+	if isUser {
+		actorHandle, r, err = getUserHandler(ctx, client)
+		if err != nil {
+			return fmt.Errorf("workspaceAggregatorCustomizeDiff.getUserHandler error  %v", decodeResponse(r))
+		}
+	} else {
+		actorHandle = orgHandle
+	}
+	resolved, r, err := resolveAggregatorConnections(ctx, client, isUser, actorHandle, d.Get("workspace").(string), d.Get("plugin").(string), entries, folders)
+	if err != nil {
+		if r != nil {
+			return fmt.Errorf("error resolving connections of aggregator: %v", decodeResponse(r))
+		}
+		return fmt.Errorf("error resolving connections of aggregator: %v", err)
+	}
+	if !sameStrings(oldResolved, resolved) {
+		return d.SetNew("resolved_connections", resolved)
+	}
+	return nil
+}
+
+// getAggregatorConnections:: Get the connections of the aggregator, resolving the selectors against the connections of the workspace
+func getAggregatorConnections(ctx context.Context, d *schema.ResourceData, client *PipesClient, isUser bool, userHandle, orgHandle, workspaceHandle, plugin string) ([]string, *http.Response, error) {
+	entries, err := convertToStringArray(d.Get("connections").([]interface{}))
+	if err != nil {
+		return nil, nil, err
+	}
+	folders, err := convertToStringArray(d.Get("connection_folders").([]interface{}))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !usesConnectionSelectors(entries, folders) {
+		return entries, nil, nil
+	}
+
+	actorHandle := orgHandle
+	if isUser {
+		actorHandle = userHandle
+	}
+	return resolveAggregatorConnections(ctx, client, isUser, actorHandle, workspaceHandle, plugin, entries, folders)
+}
+
+// resolveAggregatorConnections:: Resolve the connection handles, wildcard patterns and folders of an aggregator into the handles
+// of the connections of the aggregator plugin in the workspace. Connection handles are kept as is, even if they do not exist yet.
+func resolveAggregatorConnections(ctx context.Context, client *PipesClient, isUser bool, actorHandle, workspaceHandle, plugin string, entries, folders []string) ([]string, *http.Response, error) {
+	items, r, err := listWorkspaceConnectionTree(ctx, client, isUser, actorHandle, workspaceHandle)
+	if err != nil {
+		return nil, r, err
+	}
+	tree := buildConnectionFolderTree(items)
+
+	// Folders are referenced either by ID or by their path of titles from the root of the workspace
+	folderIdsByPath, duplicates := tree.folderIdsByPath()
+	var folderIds []string
+	for _, folder := range folders {
+		if _, ok := tree.byId[folder]; ok {
+			folderIds = append(folderIds, folder)
+		} else if id, ok := folderIdsByPath[folder]; ok {
+			folderIds = append(folderIds, id)
+		} else if slices.Contains(duplicates, folder) {
+			return nil, nil, fmt.Errorf("connection folder path %q is shared by several folders in workspace %s, reference the folder by ID", folder, workspaceHandle)
+		} else {
+			return nil, nil, fmt.Errorf("connection folder %q not found in workspace %s", folder, workspaceHandle)
+		}
+	}
+
+	resolved := []string{}
+	selected := map[string]bool{}
+	for _, entry := range entries {
+		if !isConnectionPattern(entry) && !selected[entry] {
+			selected[entry] = true
+			resolved = append(resolved, entry)
+		}
+	}
+	for _, connection := range tree.connections {
+		handle := connection.GetHandle()
+		if connection.GetType() == "aggregator" || connection.GetPlugin() != plugin || selected[handle] {
+			continue
+		}
+		matched := false
+		for _, entry := range entries {
+			if ok, _ := path.Match(entry, handle); ok && isConnectionPattern(entry) {
+				matched = true
+			}
+		}
+		for _, folderId := range folderIds {
+			if tree.isInFolder(connection.ParentId, folderId) {
+				matched = true
+			}
+		}
+		if matched {
+			selected[handle] = true
+			resolved = append(resolved, handle)
+		}
+	}
+	return resolved, nil, nil
+}
+
+// listWorkspaceConnectionTree:: List the connections, aggregators and connection folders of a user or organization workspace
+func listWorkspaceConnectionTree(ctx context.Context, client *PipesClient, isUser bool, actorHandle, workspaceHandle string) ([]pipes.Connection, *http.Response, error) {
+	var items []pipes.Connection
+	var nextToken string
+	for {
+		var resp pipes.ListConnectionsResponse
+		var r *http.Response
+		var err error
+		if isUser {
+			req := client.APIClient.UserWorkspaceConnectionTree.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		} else {
+			req := client.APIClient.OrgWorkspaceConnectionTree.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return nil, r, err
+		}
+		if resp.Items != nil {
+			items = append(items, *resp.Items...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+	return items, nil, nil
+}
+
+// aggregatorRequestConnections:: Get the connections to send for the aggregator. Wildcard patterns are sent as is so that
+// the aggregator keeps matching connections added later, while folders are sent as the connections they resolved to.
+func aggregatorRequestConnections(d *schema.ResourceData, resolved []string) []string {
+	entries, _ := convertToStringArray(d.Get("connections").([]interface{}))
+	connections := append([]string{}, entries...)
+	for _, handle := range resolved {
+		matched := false
+		for _, entry := range entries {
+			if ok, _ := path.Match(entry, handle); ok {
+				matched = true
+			}
+		}
+		if !matched {
+			connections = append(connections, handle)
+		}
+	}
+	return connections
+}
+
+// setAggregatorConnections:: Set the connections of the aggregator. When selectors are used they are kept in state as configured,
+// along with the connections they last resolved to, since the aggregator itself does not know about folders.
+func setAggregatorConnections(d *schema.ResourceData, connections []string, resolved []string) {
+	entries, _ := convertToStringArray(d.Get("connections").([]interface{}))
+	folders, _ := convertToStringArray(d.Get("connection_folders").([]interface{}))
+	if !usesConnectionSelectors(entries, folders) {
+		d.Set("connections", connections)
+		d.Set("resolved_connections", connections)
+	} else if resolved != nil {
+		d.Set("resolved_connections", resolved)
+	}
+}
+
+// refreshAggregatorConnections:: Refresh the connections of the aggregator. When selectors are used they are kept in state
+// as configured, less the entries removed from the aggregator outside Terraform, and the resolved connections are those
+// the aggregator currently matches, so that plan shows the connections added to or removed from it outside Terraform.
+func refreshAggregatorConnections(ctx context.Context, d *schema.ResourceData, client *PipesClient, isUser bool, actorHandle, workspaceHandle string, resp pipes.Aggregator) (*http.Response, error) {
+	entries, _ := convertToStringArray(d.Get("connections").([]interface{}))
+	folders, _ := convertToStringArray(d.Get("connection_folders").([]interface{}))
+	if !usesConnectionSelectors(entries, folders) {
+		setAggregatorConnections(d, resp.Connections, nil)
+		return nil, nil
+	}
+
+	d.Set("connections", aggregatorKeptEntries(entries, resp.Connections))
+	resolved := resp.Connections
+	if usesConnectionSelectors(resp.Connections, nil) {
+		var r *http.Response
+		var err error
+		resolved, r, err = resolveAggregatorConnections(ctx, client, isUser, actorHandle, workspaceHandle, resp.Plugin, resp.Connections, nil)
+		if err != nil {
+			return r, err
+		}
+	}
+	d.Set("resolved_connections", resolved)
+	return nil, nil
+}
+
+// aggregatorKeptEntries:: The configured entries of connections which the aggregator still has
+func aggregatorKeptEntries(entries, connections []string) []string {
+	present := map[string]bool{}
+	for _, connection := range connections {
+		present[connection] = true
+	}
+	kept := []string{}
+	for _, entry := range entries {
+		if present[entry] {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// usesConnectionSelectors:: Check if any connection of the aggregator is selected by wildcard pattern or folder rather than by handle
+func usesConnectionSelectors(entries, folders []string) bool {
+	if len(folders) > 0 {
+		return true
+	}
+	for _, entry := range entries {
+		if isConnectionPattern(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConnectionPattern:: Check if an entry of connections is a wildcard pattern rather than a connection handle. Only the *
+// wildcard of the aggregators of Turbot Pipes is supported, as patterns are sent as is to the API.
+func isConnectionPattern(entry string) bool {
+	return strings.Contains(entry, "*")
+}
+
+func validateConnectionPattern(v interface{}, k string) (warnings []string, errs []error) {
+	value := v.(string)
+	if strings.TrimSpace(value) == "" {
+		return nil, []error{fmt.Errorf("%s must not be empty", k)}
+	}
+	if strings.ContainsAny(value, `?[]\`) {
+		return nil, []error{fmt.Errorf("%s: invalid pattern %q, only the * wildcard is supported", k, value)}
+	}
+	return nil, nil
+}
+
+// sameStrings:: Check if two lists hold the same strings, regardless of their order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -5,12 +5,134 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+// The connection tree of a workspace with folders "aws" and "aws/prod", and two empty folders titled "old", listed over
+// two pages
+func testAggregatorConnectionTreeHandler(t *testing.T) http.HandlerFunc {
+	pages := map[string]string{
+		"": `{"items": [
+			{"id": "f_aaaaaaaaaaaaaaaaaaaa", "type": "connection-folder", "title": "aws", "parent_id": "w_aaaaaaaaaaaaaaaaaaaa"},
+			{"id": "f_bbbbbbbbbbbbbbbbbbbb", "type": "connection-folder", "title": "prod", "parent_id": "f_aaaaaaaaaaaaaaaaaaaa"},
+			{"id": "c_aaaaaaaaaaaaaaaaaaaa", "type": "connection", "handle": "aws_dev", "plugin": "aws", "parent_id": "f_aaaaaaaaaaaaaaaaaaaa"},
+			{"id": "c_bbbbbbbbbbbbbbbbbbbb", "type": "connection", "handle": "aws_prod_1", "plugin": "aws", "parent_id": "f_bbbbbbbbbbbbbbbbbbbb"}
+		], "next_token": "page2"}`,
+		"page2": `{"items": [
+			{"id": "c_cccccccccccccccccccc", "type": "connection", "handle": "aws_prod_2", "plugin": "aws", "parent_id": "w_aaaaaaaaaaaaaaaaaaaa"},
+			{"id": "c_dddddddddddddddddddd", "type": "connection", "handle": "gcp_prod", "plugin": "gcp", "parent_id": "f_bbbbbbbbbbbbbbbbbbbb"},
+			{"id": "c_eeeeeeeeeeeeeeeeeeee", "type": "aggregator", "handle": "aws_all", "plugin": "aws", "parent_id": "w_aaaaaaaaaaaaaaaaaaaa"},
+			{"id": "f_cccccccccccccccccccc", "type": "connection-folder", "title": "old", "parent_id": "w_aaaaaaaaaaaaaaaaaaaa"},
+			{"id": "f_dddddddddddddddddddd", "type": "connection-folder", "title": "old", "parent_id": "w_aaaaaaaaaaaaaaaaaaaa"}
+		]}`,
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/org/myorg/workspace/myworkspace/connection_tree" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		page, ok := pages[r.URL.Query().Get("next_token")]
+		if !ok {
+			t.Errorf("unexpected next_token %q", r.URL.Query().Get("next_token"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, page)
+	}
+}
+
+func TestResolveAggregatorConnections(t *testing.T) {
+	client := newTestClient(t, testAggregatorConnectionTreeHandler(t))
+	cases := map[string]struct {
+		entries  []string
+		folders  []string
+		expected []string
+		err      bool
+	}{
+		"handles are kept as is":        {entries: []string{"aws_dev", "aws_new"}, expected: []string{"aws_dev", "aws_new"}},
+		"pattern of the plugin":         {entries: []string{"aws_prod_*"}, expected: []string{"aws_prod_1", "aws_prod_2"}},
+		"pattern skips aggregators":     {entries: []string{"aws_*"}, expected: []string{"aws_dev", "aws_prod_1", "aws_prod_2"}},
+		"pattern skips other plugins":   {entries: []string{"*_prod*"}, expected: []string{"aws_prod_1", "aws_prod_2"}},
+		"folder by path with subfolder": {folders: []string{"aws"}, expected: []string{"aws_dev", "aws_prod_1"}},
+		"folder by ID":                  {folders: []string{"f_bbbbbbbbbbbbbbbbbbbb"}, expected: []string{"aws_prod_1"}},
+		"handles before selected ones":  {entries: []string{"aws_prod_2"}, folders: []string{"aws/prod"}, expected: []string{"aws_prod_2", "aws_prod_1"}},
+		"duplicates are left out":       {entries: []string{"aws_dev", "aws_dev", "aws_*"}, expected: []string{"aws_dev", "aws_prod_1", "aws_prod_2"}},
+		"unknown folder":                {folders: []string{"azure"}, err: true},
+		"folder by shared path":         {folders: []string{"old"}, err: true},
+		"folder by ID of shared path":   {folders: []string{"f_cccccccccccccccccccc"}, expected: []string{}},
+	}
+	for name, c := range cases {
+		resolved, _, err := resolveAggregatorConnections(context.Background(), client, false, "myorg", "myworkspace", "aws", c.entries, c.folders)
+		if c.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(resolved, c.expected) {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, resolved)
+		}
+	}
+}
+
+func TestAggregatorRequestConnections(t *testing.T) {
+	cases := map[string]struct {
+		entries  []interface{}
+		resolved []string
+		expected []string
+	}{
+		"handles":                       {entries: []interface{}{"aws_dev"}, resolved: []string{"aws_dev"}, expected: []string{"aws_dev"}},
+		"patterns are sent as is":       {entries: []interface{}{"aws_prod_*"}, resolved: []string{"aws_prod_1", "aws_prod_2"}, expected: []string{"aws_prod_*"}},
+		"folders are sent as handles":   {resolved: []string{"aws_dev", "aws_prod_1"}, expected: []string{"aws_dev", "aws_prod_1"}},
+		"folder handles of no patterns": {entries: []interface{}{"aws_prod_*"}, resolved: []string{"aws_prod_1", "aws_dev"}, expected: []string{"aws_prod_*", "aws_dev"}},
+	}
+	for name, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceWorkspaceAggregator().Schema, map[string]interface{}{
+			"workspace":   "myworkspace",
+			"handle":      "aws_all",
+			"plugin":      "aws",
+			"connections": c.entries,
+		})
+		if actual := aggregatorRequestConnections(d, c.resolved); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, actual)
+		}
+	}
+}
+
+func TestAggregatorKeptEntries(t *testing.T) {
+	// An entry removed from the aggregator outside Terraform is left out, so that plan adds it back
+	actual := aggregatorKeptEntries([]string{"aws_dev", "aws_prod_*", "aws_old"}, []string{"aws_prod_*", "aws_dev", "aws_prod_1"})
+	if expected := []string{"aws_dev", "aws_prod_*"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestValidateConnectionPattern(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"aws_dev":  true,
+		"aws_*":    true,
+		"*":        true,
+		"aws_?":    false,
+		"aws_[ab]": false,
+		`aws_\*`:   false,
+		" ":        false,
+	} {
+		_, errs := validateConnectionPattern(value, "connections.0")
+		if valid != (len(errs) == 0) {
+			t.Errorf("%q: expected valid %t, got %v", value, valid, errs)
+		}
+	}
+}
+
 // test suites
 func TestAccUserWorkspaceAggregator_Basic(t *testing.T) {
 	resourceName := "pipes_workspace_aggregator.aggregator_1"
@@ -49,6 +171,15 @@ func TestAccUserWorkspaceAggregator_Basic(t *testing.T) {
 					TestArrayEqual(t, resourceName, "connections", connections),
 				),
 			},
+			{
+				Config: testAccUserWorkspaceAggregatorUpdateConfig(workspaceHandle, updatedAggregatorHandle, plugin, `["aws1", "aws_prod_*"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWorkspaceAggregatorExists(workspaceHandle),
+					TestArrayEqual(t, resourceName, "connections", `["aws1", "aws_prod_*"]`),
+					resource.TestCheckResourceAttr(resourceName, "resolved_connections.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "resolved_connections.0", "aws1"),
+				),
+			},
 		},
 	})
 }
@@ -6,26 +6,27 @@ import (
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/turbot/go-kit/types"
 	"github.com/turbot/pipes-sdk-go"
 )
 
 func resourceWorkspaceConnection() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceWorkspaceConnectionCreate,
-		ReadContext:   resourceWorkspaceConnectionRead,
-		UpdateContext: resourceWorkspaceConnectionUpdate,
-		DeleteContext: resourceWorkspaceConnectionDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
-		Schema: map[string]*schema.Schema{
+	resource := connectionResource(connectionScope{
+		name:         "resourceWorkspaceConnection",
+		config:       connectionJSONConfig,
+		key:          workspaceConnectionKey,
+		parseId:      parseWorkspaceConnectionId,
+		formatId:     formatWorkspaceConnectionId,
+		importFormat: workspaceConnectionImportIdFormat,
+		resolveId:    resolveWorkspaceConnectionId,
+		create:       workspaceConnectionCreate,
+		get:          workspaceConnectionGet,
+		update:       workspaceConnectionUpdate,
+		delete:       workspaceConnectionDelete,
+		attributes: map[string]*schema.Schema{
 			"connection_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -199,472 +200,180 @@ func resourceWorkspaceConnection() *schema.Resource {
 				Computed: true,
 			},
 		},
-		SchemaVersion: 1,
-		StateUpgraders: []schema.StateUpgrader{
-			{
-				Type:    resourceWorkspaceConnectionV0().CoreConfigSchema().ImpliedType(),
-				Upgrade: resourceWorkspaceConnectionStateUpgradeV0,
-				Version: 0,
-			},
+	})
+	resource.SchemaVersion = 1
+	resource.StateUpgraders = []schema.StateUpgrader{
+		{
+			Type:    resourceWorkspaceConnectionV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: resourceWorkspaceConnectionStateUpgradeV0,
+			Version: 0,
 		},
 	}
+	return resource
 }
 
-func resourceWorkspaceConnectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var workspaceHandle, plugin, connHandle, configString, parentId string
-	var config map[string]interface{}
-	var err error
-	var writeConfig bool
-
-	// Get details about the workspace where the connection would be created
-	if val, ok := d.GetOk("workspace"); ok {
-		workspaceHandle = val.(string)
-	}
-
-	// Get general information about the connection to be created
-	if value, ok := d.GetOk("handle"); ok {
-		connHandle = value.(string)
-	}
-	if value, ok := d.GetOk("plugin"); ok {
-		plugin = value.(string)
-	}
-	if value, ok := d.GetOk("parent_id"); ok {
-		parentId = value.(string)
-	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
-
-	req := pipes.CreateConnectionRequest{
-		Handle: connHandle,
-		Plugin: plugin,
-	}
-
-	// Pass the parent_id if its set
-	if parentId != "" {
-		req.SetParentId(parentId)
-	}
+var workspaceConnectionIdFormat = workspaceResourceIdFormat("<connection-handle>")
 
-	// Pass the config if its set
-	if config != nil {
-		req.SetConfig(config)
+// ID Format
+// User workspace connection - WorkspaceHandle/ConnectionHandle
+// Org workspace connection - OrgHandle/WorkspaceHandle/ConnectionHandle
+func workspaceConnectionKey(d *schema.ResourceData) connectionKey {
+	scope := workspaceScopeFromData(d, "workspace")
+	return connectionKey{
+		organization: scope.organization,
+		workspace:    scope.workspace,
+		handle:       d.Get("handle").(string),
 	}
+}
 
-	client := meta.(*PipesClient)
-	var resp pipes.Connection
-	var r *http.Response
-
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceConnectionCreate. getUserHandler error %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceConnections.Create(ctx, actorHandle, workspaceHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceConnections.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
-	}
+func parseWorkspaceConnectionId(id string) (connectionKey, error) {
+	parts, err := workspaceConnectionIdFormat.parse(id)
 	if err != nil {
-		return diag.Errorf("resourceWorkspaceConnectionCreate. Create connection api error  %v", decodeResponse(r))
-	}
-
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceConnectionCreate. Error converting config to string: %v", err)
-		}
-	}
-
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("identity_id", resp.IdentityId)
-	d.Set("workspace_id", resp.WorkspaceId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
+		return connectionKey{}, err
 	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	d.Set("organization", orgHandle)
-	d.Set("workspace", workspaceHandle)
-	// ID Format
-	// User workspace connection - WorkspaceHandle/ConnectionHandle
-	// Org workspace connection - OrgHandle/WorkspaceHandle/ConnectionHandle
-	if isUser {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Handle))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Handle))
-
-	}
-
-	return diags
+	scope := workspaceScopeFromId(parts)
+	return connectionKey{organization: scope.organization, workspace: scope.workspace, handle: parts["connection-handle"]}, nil
 }
 
-func resourceWorkspaceConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	// Warning or errors can be collected in a slice type
-	var connectionHandle, orgHandle, workspaceHandle, configString string
-	var diags diag.Diagnostics
-	var isUser = false
-
-	// ID formats
-	// User workspace connection - "WorkspaceHandle/ConnectionHandle"
-	// Org workspace connection - "OrganizationHandle/WorkspaceHandle/ConnectionHandle"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <org-handle>/<workspace-handle>/<connection-handle>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		connectionHandle = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		connectionHandle = idParts[1]
-	}
-
-	if workspaceHandle == "" {
-		return diag.Errorf("resourceWorkspaceConnectionRead. Workspace information not present.")
-	}
-	if connectionHandle == "" {
-		return diag.Errorf("resourceWorkspaceConnectionRead. Connection handle not present.")
-	}
-
-	var resp pipes.WorkspaceConnection
-	var r *http.Response
-	var err error
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceConnectionCreate. getUserHandler error %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceConnections.Get(ctx, actorHandle, workspaceHandle, connectionHandle).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceConnections.Get(ctx, orgHandle, workspaceHandle, connectionHandle).Execute()
-	}
+// A connection may also be imported by its ID alone, e.g. "c_cdi2d6c3ul7ugl1l6e30"
+var workspaceConnectionImportIdFormat = newResourceIdFormat(
+	"<connection-id>",
+	"<workspace-handle>/<connection-handle>",
+	"<org-handle>/<workspace-handle>/<connection-handle>",
+).withLegacySeparator(":")
+
+// resolveWorkspaceConnectionId:: Resolve a workspace connection imported by its ID, along with its workspace and
+// organization when imported by its ID alone
+func resolveWorkspaceConnectionId(ctx context.Context, client *PipesClient, id resourceId) error {
+	connectionId, alone, err := importedConnectionId(id)
 	if err != nil {
-		return diag.Errorf("resourceWorkspaceConnectionCreate. Create connection api error  %v", decodeResponse(r))
+		return err
 	}
-
-	// Convert config to string
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceOrganizationConnectionRead. Error converting config to string: %v", err)
-		}
+	if !alone {
+		return resolveConnectionHandle(ctx, client, id, workspaceConnectionGet, connectionKey{organization: id["org-handle"], workspace: id["workspace-handle"], handle: id["connection-handle"]})
 	}
 
-	_, writeConfig := d.GetOk("config")
-
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("identity_id", resp.IdentityId)
-	d.Set("workspace_id", resp.WorkspaceId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
-	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
+	conn, err := findActorConnection(ctx, client, connectionId)
+	if err != nil {
+		return err
 	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
+	if conn.GetWorkspaceId() == "" {
+		return fmt.Errorf("connection %s is not a connection of a workspace", connectionId)
 	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
+	if err = setWorkspaceFromId(ctx, client, id, conn.GetWorkspaceId()); err != nil {
+		return err
 	}
-	d.Set("organization", orgHandle)
-	d.Set("workspace", workspaceHandle)
-	// ID Format
-	// User workspace connection - WorkspaceHandle/ConnectionHandle
-	// Org workspace connection - OrgHandle/WorkspaceHandle/ConnectionHandle
-	if isUser {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Handle))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Handle))
-
+	if id["workspace-handle"] == "" {
+		return fmt.Errorf("workspace %s of connection %s not found", conn.GetWorkspaceId(), connectionId)
 	}
-
-	return diags
+	id["connection-handle"] = conn.GetHandle()
+	return nil
 }
 
-func resourceWorkspaceConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	var workspaceHandle, configString string
-	var config map[string]interface{}
-	var writeConfig bool
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-
-	// Get details about the workspace where the integration would be created
-	if val, ok := d.GetOk("workspace"); ok {
-		workspaceHandle = val.(string)
-	}
-
-	oldConnectionHandle, newConnectionHandle := d.GetChange("handle")
-	if newConnectionHandle.(string) == "" {
-		return diag.Errorf("handle must be configured")
-	}
-
-	// Parse config OR config_wo (if provided)
-	if value, ok := d.GetOk("config"); ok {
-		writeConfig = true
-		_, config = formatConnectionJSONString(value.(string))
-	}
-	if value, ok := d.GetRawConfig().AsValueMap()["config_wo"]; ok && !value.IsNull() {
-		_, config = formatConnectionJSONString(value.AsString())
-	}
+func formatWorkspaceConnectionId(key connectionKey) string {
+	return workspaceConnectionScope(key).id(key.handle)
+}
 
-	req := pipes.UpdateConnectionRequest{Handle: types.String(newConnectionHandle.(string))}
-	if config != nil {
-		req.SetConfig(config)
-	}
-	if ok := d.HasChange("parent_id"); ok {
-		if value, ok := d.GetOk("parent_id"); ok {
-			req.SetParentId(value.(string))
-		}
-	}
-	if ok := d.HasChange("config_source"); ok {
-		if value, ok := d.GetOk("config_source"); ok {
-			req.SetConfigSource(pipes.ConnectionConfigSource(value.(string)))
-		}
-	}
-	if ok := d.HasChange("credential_source"); ok {
-		if value, ok := d.GetOk("credential_source"); ok {
-			req.SetCredentialSource(pipes.ConnectionCredentialSource(value.(string)))
-		}
-	}
+func workspaceConnectionCreate(ctx context.Context, client *PipesClient, key connectionKey, req pipes.CreateConnectionRequest) (pipes.Connection, *http.Response, error) {
+	return workspaceScopeCall(ctx, client, workspaceConnectionScope(key),
+		func(userHandle string) (pipes.Connection, *http.Response, error) {
+			return client.APIClient.UserWorkspaceConnections.Create(ctx, userHandle, key.workspace).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Connection, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceConnections.Create(ctx, orgHandle, key.workspace).Request(req).Execute()
+		},
+	)
+}
 
-	var resp pipes.Connection
-	var err error
-	var r *http.Response
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceConnectionUpdate. getUserHandler error %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceConnections.Update(ctx, actorHandle, workspaceHandle, oldConnectionHandle.(string)).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceConnections.Update(ctx, orgHandle, workspaceHandle, oldConnectionHandle.(string)).Request(req).Execute()
-	}
+func workspaceConnectionGet(ctx context.Context, client *PipesClient, key connectionKey) (pipes.Connection, *http.Response, error) {
+	resp, r, err := workspaceScopeCall(ctx, client, workspaceConnectionScope(key),
+		func(userHandle string) (pipes.WorkspaceConnection, *http.Response, error) {
+			return client.APIClient.UserWorkspaceConnections.Get(ctx, userHandle, key.workspace, key.handle).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceConnection, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceConnections.Get(ctx, orgHandle, key.workspace, key.handle).Execute()
+		},
+	)
 	if err != nil {
-		return diag.Errorf("resourceWorkspaceConnectionUpdate. Create connection api error  %v", decodeResponse(r))
-	}
-
-	if resp.GetConfig() != nil {
-		configString, err = mapToJSONString(resp.GetConfig())
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceConnectionUpdate. Error converting config to string: %v", err)
-		}
-	}
-
-	d.Set("connection_id", resp.Id)
-	d.Set("tenant_id", resp.TenantId)
-	d.Set("identity_id", resp.IdentityId)
-	d.Set("workspace_id", resp.WorkspaceId)
-	d.Set("handle", resp.Handle)
-	d.Set("plugin", resp.Plugin)
-	d.Set("plugin_version", resp.PluginVersion)
-	d.Set("type", resp.Type)
-	if writeConfig && configString != "" && configString != "null" {
-		d.Set("config", configString)
-	}
-	d.Set("config_source", resp.ConfigSource)
-	d.Set("credential_source", resp.CredentialSource)
-	d.Set("handle_mode", resp.HandleMode)
-	d.Set("handle_dynamic", resp.HandleDynamic)
-	d.Set("parent_id", resp.ParentId)
-	d.Set("integration_resource_name", resp.IntegrationResourceName)
-	d.Set("integration_resource_identifier", resp.IntegrationResourceIdentifier)
-	d.Set("integration_resource_type", resp.IntegrationResourceType)
-	d.Set("integration_resource_path", resp.IntegrationResourcePath)
-	d.Set("managed_by_id", resp.ManagedById)
-	d.Set("trunk", resp.Trunk)
-	d.Set("created_at", resp.CreatedAt)
-	d.Set("updated_at", resp.UpdatedAt)
-	if resp.CreatedBy != nil {
-		d.Set("created_by", resp.CreatedBy.Handle)
-	}
-	if resp.UpdatedBy != nil {
-		d.Set("updated_by", resp.UpdatedBy.Handle)
-	}
-	d.Set("version_id", resp.VersionId)
-	if resp.Status != nil {
-		d.Set("status", resp.Status)
-	}
-	if resp.LastErrorAt != nil {
-		d.Set("last_error_at", resp.LastErrorAt)
-	}
-	if resp.LastErrorProcessId != nil {
-		d.Set("last_error_process_id", resp.LastErrorProcessId)
-	}
-	if resp.LastSuccessfulUpdateAt != nil {
-		d.Set("last_successful_update_at", resp.LastSuccessfulUpdateAt)
+		return pipes.Connection{}, r, err
 	}
-	if resp.LastSuccessfulUpdateProcessId != nil {
-		d.Set("last_successful_update_process_id", resp.LastSuccessfulUpdateProcessId)
-	}
-	if resp.LastUpdateAttemptAt != nil {
-		d.Set("last_update_attempt_at", resp.LastUpdateAttemptAt)
-	}
-	if resp.LastUpdateAttemptProcessId != nil {
-		d.Set("last_update_attempt_process_id", resp.LastUpdateAttemptProcessId)
-	}
-	d.Set("organization", orgHandle)
-	d.Set("workspace", workspaceHandle)
-	// ID Format
-	// User workspace connection - WorkspaceHandle/ConnectionHandle
-	// Org workspace connection - OrgHandle/WorkspaceHandle/ConnectionHandle
-	if isUser {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Handle))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Handle))
 
-	}
-
-	return diags
+	return workspaceConnectionToConnection(resp), r, nil
 }
 
-func resourceWorkspaceConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*PipesClient)
-
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, connectionHandle string
-	var isUser = false
-
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <org-handle>/<workspace-handle>/<connection-handle>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		connectionHandle = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		connectionHandle = idParts[1]
+// workspaceConnectionToConnection:: The connection of a workspace connection, which is a connection along with its
+// association to the workspace, not part of the resource
+func workspaceConnectionToConnection(wc pipes.WorkspaceConnection) pipes.Connection {
+	return pipes.Connection{
+		Config:                        wc.Config,
+		ConfigSource:                  wc.ConfigSource,
+		CreatedAt:                     wc.CreatedAt,
+		CreatedBy:                     wc.CreatedBy,
+		CreatedById:                   wc.CreatedById,
+		CredentialSource:              wc.CredentialSource,
+		DeletedAt:                     wc.DeletedAt,
+		DeletedBy:                     wc.DeletedBy,
+		DeletedById:                   wc.DeletedById,
+		Handle:                        wc.Handle,
+		HandleDynamic:                 wc.HandleDynamic,
+		HandleMode:                    wc.HandleMode,
+		Id:                            wc.Id,
+		IdentityId:                    wc.IdentityId,
+		Integration:                   wc.Integration,
+		IntegrationResourceIdentifier: wc.IntegrationResourceIdentifier,
+		IntegrationResourceName:       wc.IntegrationResourceName,
+		IntegrationResourcePath:       wc.IntegrationResourcePath,
+		IntegrationResourceType:       wc.IntegrationResourceType,
+		LastErrorAt:                   wc.LastErrorAt,
+		LastErrorProcessId:            wc.LastErrorProcessId,
+		LastSuccessfulUpdateAt:        wc.LastSuccessfulUpdateAt,
+		LastSuccessfulUpdateProcessId: wc.LastSuccessfulUpdateProcessId,
+		LastUpdateAttemptAt:           wc.LastUpdateAttemptAt,
+		LastUpdateAttemptProcessId:    wc.LastUpdateAttemptProcessId,
+		ManagedById:                   wc.ManagedById,
+		ParentId:                      wc.ParentId,
+		Plugin:                        wc.Plugin,
+		PluginVersion:                 wc.PluginVersion,
+		Status:                        wc.Status,
+		TenantId:                      wc.TenantId,
+		Title:                         wc.Title,
+		Trunk:                         wc.Trunk,
+		Type:                          wc.Type,
+		UpdatedAt:                     wc.UpdatedAt,
+		UpdatedBy:                     wc.UpdatedBy,
+		UpdatedById:                   wc.UpdatedById,
+		VersionId:                     wc.VersionId,
+		WorkspaceId:                   wc.WorkspaceId,
 	}
+}
 
-	log.Printf("\n[DEBUG] Deleting Workspace Connection: %s", fmt.Sprintf("%s/%s", workspaceHandle, connectionHandle))
+func workspaceConnectionUpdate(ctx context.Context, client *PipesClient, key connectionKey, req pipes.UpdateConnectionRequest) (pipes.Connection, *http.Response, error) {
+	return workspaceScopeCall(ctx, client, workspaceConnectionScope(key),
+		func(userHandle string) (pipes.Connection, *http.Response, error) {
+			return client.APIClient.UserWorkspaceConnections.Update(ctx, userHandle, key.workspace, key.handle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Connection, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceConnections.Update(ctx, orgHandle, key.workspace, key.handle).Request(req).Execute()
+		},
+	)
+}
 
-	var err error
-	var r *http.Response
+func workspaceConnectionDelete(ctx context.Context, client *PipesClient, key connectionKey) (*http.Response, error) {
+	log.Printf("\n[DEBUG] Deleting Workspace Connection: %s", fmt.Sprintf("%s/%s", key.workspace, key.handle))
 
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceConnectionDelete. getUserHandler error: %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspaceConnections.Delete(ctx, actorHandle, workspaceHandle, connectionHandle).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspaceConnections.Delete(ctx, orgHandle, workspaceHandle, connectionHandle).Execute()
-	}
-
-	if err != nil {
-		return diag.Errorf("error deleting workspace connection: %v", decodeResponse(r))
-	}
-	d.SetId("")
+	_, r, err := workspaceScopeCall(ctx, client, workspaceConnectionScope(key),
+		func(userHandle string) (pipes.WorkspaceConnection, *http.Response, error) {
+			return client.APIClient.UserWorkspaceConnections.Delete(ctx, userHandle, key.workspace, key.handle).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceConnection, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceConnections.Delete(ctx, orgHandle, key.workspace, key.handle).Execute()
+		},
+	)
+	return r, err
+}
 
-	return diags
+func workspaceConnectionScope(key connectionKey) workspaceScope {
+	return workspaceScope{organization: key.organization, workspace: key.workspace}
 }
 
 func resourceWorkspaceConnectionV0() *schema.Resource {
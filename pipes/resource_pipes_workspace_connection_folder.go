@@ -4,22 +4,21 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceConnectionFolderIdFormat = workspaceResourceIdFormat("<connection-folder-id>")
+
 func resourceWorkspaceConnectionFolder() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceConnectionFolderCreate,
 		ReadContext:   resourceWorkspaceConnectionFolderRead,
 		UpdateContext: resourceWorkspaceConnectionFolderUpdate,
 		DeleteContext: resourceWorkspaceConnectionFolderDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceConnectionFolderIdFormat),
 		Schema: map[string]*schema.Schema{
 			"connection_folder_id": {
 				Type:     schema.TypeString,
@@ -208,23 +207,12 @@ func resourceWorkspaceConnectionFolderRead(ctx context.Context, d *schema.Resour
 	var diags diag.Diagnostics
 
 	// Id would be of format "WorkspaceHandle/ConnectionFolderId" or "OrganizationHandle/WorkspaceHandle/ConnectionFolderId"
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 3 {
-		orgHandle = ids[0]
-		workspaceHandle = ids[1]
-		connectionFolderId = ids[2]
-	} else if len(ids) == 2 {
-		workspaceHandle = ids[0]
-		connectionFolderId = ids[1]
-		isUser = true
-	}
-
-	if workspaceHandle == "" {
-		return diag.Errorf("resourceWorkspaceConnectionFolderRead. Workspace information not present.")
-	}
-	if connectionFolderId == "" {
-		return diag.Errorf("resourceWorkspaceConnectionFolderRead. Connection folder id not present.")
+	id, err := workspaceConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, connectionFolderId = id["org-handle"], id["workspace-handle"], id["connection-folder-id"]
+	isUser = orgHandle == ""
 
 	if isUser {
 		var actorHandle string
@@ -294,16 +282,12 @@ func resourceWorkspaceConnectionFolderUpdate(ctx context.Context, d *schema.Reso
 	isUser := false
 
 	// Id would be of format "WorkspaceHandle/ConnectionFolderId" or "OrganizationHandle/WorkspaceHandle/ConnectionFolderId"
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 3 {
-		orgHandle = ids[0]
-		workspaceHandle = ids[1]
-		connectionFolderId = ids[2]
-	} else if len(ids) == 2 {
-		workspaceHandle = ids[0]
-		connectionFolderId = ids[1]
-		isUser = true
+	id, err := workspaceConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, connectionFolderId = id["org-handle"], id["workspace-handle"], id["connection-folder-id"]
+	isUser = orgHandle == ""
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
@@ -385,16 +369,12 @@ func resourceWorkspaceConnectionFolderDelete(ctx context.Context, d *schema.Reso
 	isUser := false
 
 	// Id would be of format "WorkspaceHandle/ConnectionFolderId" or "OrganizationHandle/WorkspaceHandle/ConnectionFolderId"
-	ids := strings.Split(d.Id(), "/")
-	if len(ids) == 3 {
-		orgHandle = ids[0]
-		workspaceHandle = ids[1]
-		connectionFolderId = ids[2]
-	} else if len(ids) == 2 {
-		workspaceHandle = ids[0]
-		connectionFolderId = ids[1]
-		isUser = true
+	id, err := workspaceConnectionFolderIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, connectionFolderId = id["org-handle"], id["workspace-handle"], id["connection-folder-id"]
+	isUser = orgHandle == ""
 
 	if isUser {
 		var actorHandle string
@@ -0,0 +1,139 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceWorkspaceConnectionPlugin:: A workspace connection of a given plugin, whose config arguments are first-class attributes
+// generated from the config schema of the plugin. Secrets are write-only, and the connection is managed through the same
+// API calls as pipes_workspace_connection.
+func resourceWorkspaceConnectionPlugin(plugin string) *schema.Resource {
+	attributes := map[string]*schema.Schema{}
+	for key, value := range workspaceConnectionAttributes {
+		switch key {
+		case "config", "config_wo", "config_wo_version":
+		default:
+			attributes[key] = value
+		}
+	}
+	attributes["plugin"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	hasSecrets := false
+	for name, argument := range connectionConfigSchemas[plugin] {
+		attributes[connectionConfigAttributeName(plugin, name)] = connectionConfigArgumentSchema(argument)
+		hasSecrets = hasSecrets || argument.Secret
+	}
+	if hasSecrets {
+		attributes["secrets_wo_version"] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+		}
+	}
+
+	resource := connectionResource(connectionScope{
+		name:         "resourceWorkspaceConnectionPlugin",
+		attributes:   attributes,
+		config:       workspaceConnectionPluginConfig(plugin),
+		key:          workspaceConnectionKey,
+		parseId:      parseWorkspaceConnectionId,
+		formatId:     formatWorkspaceConnectionId,
+		importFormat: workspaceConnectionImportIdFormat,
+		resolveId:    resolveWorkspaceConnectionId,
+		create:       workspaceConnectionCreate,
+		get:          workspaceConnectionGet,
+		update:       workspaceConnectionUpdate,
+		delete:       workspaceConnectionDelete,
+	})
+	// The config arguments are validated by the schema of the resource
+	resource.CustomizeDiff = nil
+
+	read := resource.ReadContext
+	resource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := read(ctx, d, meta)
+		if !diags.HasError() && d.Id() != "" && connectionPluginName(d.Get("plugin").(string)) != plugin {
+			return diag.Errorf("connection %s uses the %s plugin, and cannot be managed as a pipes_workspace_connection_%s", d.Id(), d.Get("plugin"), plugin)
+		}
+		return diags
+	}
+	return resource
+}
+
+// workspaceConnectionAttributes:: The attributes of a workspace connection, which the config arguments must not clash with
+var workspaceConnectionAttributes = resourceWorkspaceConnection().Schema
+
+// connectionConfigAttributeName:: The attribute of a config argument, prefixed with the plugin name when the argument
+// clashes with an attribute of the connection, e.g. azure_tenant_id for the tenant_id of the azure plugin
+func connectionConfigAttributeName(plugin, name string) string {
+	if _, ok := workspaceConnectionAttributes[name]; ok {
+		return fmt.Sprintf("%s_%s", plugin, name)
+	}
+	return name
+}
+
+func connectionConfigArgumentSchema(argument connectionConfigArgument) *schema.Schema {
+	if argument.Secret {
+		return &schema.Schema{
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+			WriteOnly: true,
+		}
+	}
+	switch argument.Type {
+	case "number":
+		return &schema.Schema{Type: schema.TypeFloat, Optional: true}
+	case "bool":
+		return &schema.Schema{Type: schema.TypeBool, Optional: true}
+	case "list(string)":
+		return &schema.Schema{Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}}
+	}
+	return &schema.Schema{Type: schema.TypeString, Optional: true}
+}
+
+// workspaceConnectionPluginConfig:: The config of a typed workspace connection, built from its attributes
+func workspaceConnectionPluginConfig(plugin string) connectionConfig {
+	arguments := connectionConfigSchemas[plugin]
+	return connectionConfig{
+		expand: func(d *schema.ResourceData) (string, map[string]interface{}) {
+			config := map[string]interface{}{}
+			for name, argument := range arguments {
+				attribute := connectionConfigAttributeName(plugin, name)
+				// Write-only secrets can only be read from the configuration
+				value := d.GetRawConfig().GetAttr(attribute)
+				if value.IsNull() || !value.IsKnown() {
+					continue
+				}
+				if argument.Secret {
+					config[name] = value.AsString()
+				} else {
+					config[name] = d.Get(attribute)
+				}
+			}
+			if len(config) == 0 {
+				return plugin, nil
+			}
+			return plugin, config
+		},
+		flatten: func(d *schema.ResourceData, config map[string]interface{}) error {
+			if config == nil {
+				return nil
+			}
+			for name, argument := range arguments {
+				if argument.Secret {
+					continue
+				}
+				if err := d.Set(connectionConfigAttributeName(plugin, name), config[name]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package pipes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestConnectionConfigAttributeName(t *testing.T) {
+	cases := []struct {
+		plugin, name, expected string
+	}{
+		{"aws", "regions", "regions"},
+		{"azure", "tenant_id", "azure_tenant_id"},
+		{"github", "token", "token"},
+	}
+	for _, c := range cases {
+		if _, ok := workspaceConnectionAttributes[c.name]; ok != (c.expected != c.name) {
+			t.Fatalf("%s: expected the attribute to clash with the connection only when prefixed", c.name)
+		}
+		if actual := connectionConfigAttributeName(c.plugin, c.name); actual != c.expected {
+			t.Errorf("connectionConfigAttributeName(%q, %q): expected %q, got %q", c.plugin, c.name, c.expected, actual)
+		}
+	}
+}
+
+func TestWorkspaceConnectionPluginConfigNumber(t *testing.T) {
+	if actual := connectionConfigArgumentSchema(connectionConfigArgument{Type: "number"}).Type; actual != schema.TypeFloat {
+		t.Fatalf("expected number arguments to be floats, got %s", actual)
+	}
+
+	// Fractional values of number arguments are kept as is
+	d := schema.TestResourceDataRaw(t, resourceWorkspaceConnectionPlugin("aws").Schema, map[string]interface{}{})
+	err := workspaceConnectionPluginConfig("aws").flatten(d, map[string]interface{}{"min_error_retry_delay": 2.5, "max_error_retry_attempts": float64(9)})
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	if actual := d.Get("min_error_retry_delay"); actual != 2.5 {
+		t.Errorf("min_error_retry_delay: expected 2.5, got %v", actual)
+	}
+	if actual := d.Get("max_error_retry_attempts"); actual != float64(9) {
+		t.Errorf("max_error_retry_attempts: expected 9, got %v", actual)
+	}
+}
+
+func TestAccWorkspaceConnectionAws_Basic(t *testing.T) {
+	resourceName := "pipes_workspace_connection_aws.test_conn"
+	workspaceHandle := "workspace" + randomString(6)
+	connHandle := "aws_" + randomString(4)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWorkspaceConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkspaceConnectionAwsConfig(workspaceHandle, connHandle, `["us-east-1"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWorkspaceConnectionExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "plugin", "aws"),
+					resource.TestCheckResourceAttr(resourceName, "handle", connHandle),
+					resource.TestCheckResourceAttr(resourceName, "regions.#", "1"),
+					resource.TestCheckNoResourceAttr(resourceName, "secret_key"),
+				),
+			},
+			{
+				Config: testAccWorkspaceConnectionAwsConfig(workspaceHandle, connHandle, `["us-east-1", "us-east-2"]`),
+				Check:  resource.TestCheckResourceAttr(resourceName, "regions.#", "2"),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secrets_wo_version"},
+			},
+		},
+	})
+}
+
+func testAccWorkspaceConnectionAwsConfig(workspace, conn, regions string) string {
+	return fmt.Sprintf(`
+resource "pipes_workspace" "test_conn" {
+	handle = "%s"
+}
+
+resource "pipes_workspace_connection_aws" "test_conn" {
+	workspace          = pipes_workspace.test_conn.handle
+	handle             = "%s"
+	regions            = %s
+	access_key         = "redacted"
+	secret_key         = "redacted"
+	secrets_wo_version = 1
+}`, workspace, conn, regions)
+}
@@ -5,12 +5,47 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/turbot/pipes-sdk-go"
 )
 
+// Every field of a connection is mapped from the workspace connection, so that a field added to the SDK is not
+// silently left out
+func TestWorkspaceConnectionToConnection(t *testing.T) {
+	var wc pipes.WorkspaceConnection
+	value := reflect.ValueOf(&wc).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.Ptr:
+			field.Set(reflect.New(field.Type().Elem()))
+		case reflect.String:
+			field.SetString(value.Type().Field(i).Name)
+		case reflect.Int32:
+			field.SetInt(int64(i + 1))
+		default:
+			t.Fatalf("unexpected kind %s of field %s", field.Kind(), value.Type().Field(i).Name)
+		}
+	}
+
+	conn := reflect.ValueOf(workspaceConnectionToConnection(wc))
+	for i := 0; i < conn.NumField(); i++ {
+		name := conn.Type().Field(i).Name
+		expected := value.FieldByName(name)
+		if !expected.IsValid() {
+			t.Errorf("field %s of the connection is not a field of the workspace connection", name)
+			continue
+		}
+		if !reflect.DeepEqual(conn.Field(i).Interface(), expected.Interface()) {
+			t.Errorf("field %s: expected %v, got %v", name, expected.Interface(), conn.Field(i).Interface())
+		}
+	}
+}
+
 func TestAccWorkspaceConnection_Basic(t *testing.T) {
 	resourceName := "pipes_workspace_connection.test_conn"
 	workspaceHandle := "workspace" + randomString(6)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,15 +12,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceDatatankIdFormat = workspaceResourceIdFormat("<datatank-handle>")
+
 func resourceWorkspaceDatatank() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceDatatankCreate,
 		ReadContext:   resourceWorkspaceDatatankRead,
 		UpdateContext: resourceWorkspaceDatatankUpdate,
 		DeleteContext: resourceWorkspaceDatatankDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceDatatankIdFormat),
 		Schema: map[string]*schema.Schema{
 			"datatank_id": {
 				Type:     schema.TypeString,
@@ -131,23 +130,21 @@ func resourceWorkspaceDatatankCreate(ctx context.Context, d *schema.ResourceData
 	var resp pipes.Datatank
 	var r *http.Response
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceDatatankCreate. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceDatatanks.Create(ctx, actorHandle, workspaceHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceDatatanks.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.UserWorkspaceDatatanks.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceDatatanks.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
+		},
+	)
 	if err != nil {
 		return diag.Errorf("resourceWorkspaceDatatankCreate. Create datatank api error  %v", decodeResponse(r))
 	}
 
 	d.Set("datatank_id", resp.Id)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("identity_id", resp.IdentityId)
 	d.Set("workspace_handle", workspaceHandle)
 	d.Set("workspace_id", resp.WorkspaceId)
@@ -168,11 +165,7 @@ func resourceWorkspaceDatatankCreate(ctx context.Context, d *schema.ResourceData
 
 	// If datatank is created for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/DatatankHandle" otherwise "WorkspaceHandle/DatatankHandle"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Handle))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Handle))
-	}
+	d.SetId(scope.id(resp.Handle))
 
 	return diags
 }
@@ -182,44 +175,31 @@ func resourceWorkspaceDatatankRead(ctx context.Context, d *schema.ResourceData,
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, datatankHandle string
-	var isUser = false
 
 	// If datatank is created for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/DatatankHandle" otherwise "WorkspaceHandle/DatatankHandle"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<datatank-handle>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		datatankHandle = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		datatankHandle = idParts[1]
+	id, err := workspaceDatatankIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, datatankHandle := scope.workspace, id["datatank-handle"]
 
 	if datatankHandle == "" {
 		return diag.Errorf("resourceWorkspaceDatatankRead. Datatank handle not present.")
 	}
 
 	var resp pipes.Datatank
-	var err error
 	var r *http.Response
 
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceDatatankRead. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceDatatanks.Get(context.Background(), actorHandle, workspaceHandle, datatankHandle).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceDatatanks.Get(context.Background(), orgHandle, workspaceHandle, datatankHandle).Execute()
-	}
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.UserWorkspaceDatatanks.Get(ctx, userHandle, workspaceHandle, datatankHandle).Execute()
+		},
+		func(orgHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceDatatanks.Get(ctx, orgHandle, workspaceHandle, datatankHandle).Execute()
+		},
+	)
 	if err != nil {
 		if r.StatusCode == 404 {
 			diags = append(diags, diag.Diagnostic{
@@ -233,7 +213,7 @@ func resourceWorkspaceDatatankRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	d.Set("datatank_id", resp.Id)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("identity_id", resp.IdentityId)
 	d.Set("workspace_handle", workspaceHandle)
 	d.Set("workspace_id", resp.WorkspaceId)
@@ -254,11 +234,7 @@ func resourceWorkspaceDatatankRead(ctx context.Context, d *schema.ResourceData,
 
 	// If datatank is created for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/DatatankHandle" otherwise "WorkspaceHandle/DatatankHandle"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Handle))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Handle))
-	}
+	d.SetId(scope.id(resp.Handle))
 
 	return diags
 }
@@ -294,23 +270,21 @@ func resourceWorkspaceDatatankUpdate(ctx context.Context, d *schema.ResourceData
 		req.DesiredState = (*pipes.DesiredState)(&desiredState)
 	}
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceDatatankUpdate. getUserHandler error:	%v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceDatatanks.Update(context.Background(), actorHandle, workspaceHandle, datatankHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceDatatanks.Update(context.Background(), orgHandle, workspaceHandle, datatankHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.UserWorkspaceDatatanks.Update(ctx, userHandle, workspaceHandle, datatankHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceDatatanks.Update(ctx, orgHandle, workspaceHandle, datatankHandle).Request(req).Execute()
+		},
+	)
 	if err != nil {
 		return diag.Errorf("resourceWorkspaceDatatankUpdate. Update datatank error: %v", decodeResponse(r))
 	}
 
 	d.Set("datatank_id", resp.Id)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("identity_id", resp.IdentityId)
 	d.Set("workspace_handle", workspaceHandle)
 	d.Set("workspace_id", resp.WorkspaceId)
@@ -331,11 +305,7 @@ func resourceWorkspaceDatatankUpdate(ctx context.Context, d *schema.ResourceData
 
 	// If datatank is created for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/DatatankHandle" otherwise "WorkspaceHandle/DatatankHandle"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Handle))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Handle))
-	}
+	d.SetId(scope.id(resp.Handle))
 
 	return diags
 }
@@ -356,17 +326,15 @@ func resourceWorkspaceDatatankDelete(ctx context.Context, d *schema.ResourceData
 
 	var err error
 	var r *http.Response
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceDatatankDelete. getUserHandler error: %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspaceDatatanks.Delete(ctx, actorHandle, workspaceHandle, datatankHandle).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspaceDatatanks.Delete(ctx, orgHandle, workspaceHandle, datatankHandle).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	_, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.UserWorkspaceDatatanks.Delete(ctx, userHandle, workspaceHandle, datatankHandle).Execute()
+		},
+		func(orgHandle string) (pipes.Datatank, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceDatatanks.Delete(ctx, orgHandle, workspaceHandle, datatankHandle).Execute()
+		},
+	)
 
 	if err != nil {
 		return diag.Errorf("resourceWorkspaceDatatankDelete. Delete datatank error:	%v", decodeResponse(r))
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,15 +14,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceDatatankTableIdFormat = workspaceResourceIdFormat("<datatank-handle>", "<datatank-table-name>")
+
 func resourceWorkspaceDatatankTable() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceDatatankTableCreate,
 		ReadContext:   resourceWorkspaceDatatankTableRead,
 		UpdateContext: resourceWorkspaceDatatankTableUpdate,
 		DeleteContext: resourceWorkspaceDatatankTableDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceDatatankTableIdFormat),
 		Schema: map[string]*schema.Schema{
 			"datatank_table_id": {
 				Type:     schema.TypeString,
@@ -279,29 +278,18 @@ func resourceWorkspaceDatatankTableRead(ctx context.Context, d *schema.ResourceD
 
 	// If datatank table is created for a datatank in a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/DatatankHandle/DatatankTableName" otherwise "WorkspaceHandle/DatatankHandle/DatatankTableName"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 3 && len(idParts) > 4 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<datatank-handle>/<datatank-table-name>", d.Id())
-	}
-
-	if len(idParts) == 4 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		datatankHandle = idParts[2]
-		datatankTableName = idParts[3]
-	} else if len(idParts) == 3 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		datatankHandle = idParts[1]
-		datatankTableName = idParts[2]
+	id, err := workspaceDatatankTableIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, datatankHandle, datatankTableName = id["org-handle"], id["workspace-handle"], id["datatank-handle"], id["datatank-table-name"]
+	isUser = orgHandle == ""
 
 	if datatankHandle == "" {
 		return diag.Errorf("resourceWorkspaceDatatankTableRead. Datatank handle not present.")
 	}
 
 	var resp pipes.DatatankTable
-	var err error
 	var r *http.Response
 
 	if isUser {
@@ -0,0 +1,209 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func resourceWorkspaceDatatankTableRefresh() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkspaceDatatankTableRefreshCreate,
+		ReadContext:   resourceWorkspaceDatatankTableRefreshRead,
+		UpdateContext: resourceWorkspaceDatatankTableRefreshRead,
+		DeleteContext: resourceWorkspaceProcessRunDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"workspace_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"datatank_handle": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_completion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"part_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"process_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"refreshed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceWorkspaceDatatankTableRefreshCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	var r *http.Response
+	var err error
+
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	datatankHandle := d.Get("datatank_handle").(string)
+	datatankTableName := d.Get("name").(string)
+
+	parts, r, err := listDatatankParts(ctx, client, scope, datatankHandle, datatankTableName)
+	if err != nil {
+		return diag.Errorf("error listing parts of datatank table %s: %v", datatankTableName, decodeResponse(r))
+	}
+	if len(parts) == 0 {
+		return diag.Errorf("datatank table %s has no parts to refresh", datatankTableName)
+	}
+
+	// Request a refresh of every part of the table, remembering the last update attempt of each part
+	// so that the process spawned by the refresh can be told apart from earlier ones
+	refreshedAt := time.Now().UTC().Format(time.RFC3339)
+	req := pipes.CmdDatatankPartRequest{Command: pipes.DtpcRefresh}
+	previousProcessIds := map[string]string{}
+	partIds := []string{}
+	for _, part := range parts {
+		previousProcessIds[part.Id] = part.GetLastUpdateAttemptProcessId()
+		partIds = append(partIds, part.Id)
+
+		_, r, err = workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.DatatankPart, *http.Response, error) {
+				return client.APIClient.UserWorkspaceDatatankParts.Command(ctx, userHandle, scope.workspace, datatankHandle, datatankTableName, part.Id).Request(req).Execute()
+			},
+			func(orgHandle string) (pipes.DatatankPart, *http.Response, error) {
+				// the org workspace command takes no request body, refresh being its only command
+				return client.APIClient.OrgWorkspaceDatatankParts.Command(ctx, orgHandle, scope.workspace, datatankHandle, datatankTableName, part.Id).Execute()
+			},
+		)
+		if err != nil {
+			return diag.Errorf("error refreshing part %s of datatank table %s: %v", part.Id, datatankTableName, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Refresh requested for part: %s of datatank table: %s", part.Id, datatankTableName)
+	}
+
+	// If datatank table is created for a datatank in a workspace inside an Organization the id will be of the
+	// format "OrganizationHandle/WorkspaceHandle/DatatankHandle/DatatankTableName" otherwise "WorkspaceHandle/DatatankHandle/DatatankTableName"
+	d.SetId(scope.id(datatankHandle, datatankTableName))
+	d.Set("part_ids", partIds)
+	d.Set("refreshed_at", refreshedAt)
+
+	if !d.Get("wait_for_completion").(bool) {
+		d.Set("process_ids", []string{})
+		return nil
+	}
+
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
+	processIds := []string{}
+	for _, partId := range partIds {
+		var processId string
+		err = resource.RetryContext(ctx, time.Until(deadline), func() *resource.RetryError {
+			part, r, err := workspaceScopeCall(ctx, client, scope,
+				func(userHandle string) (pipes.DatatankPart, *http.Response, error) {
+					return client.APIClient.UserWorkspaceDatatankParts.Get(ctx, userHandle, scope.workspace, datatankHandle, datatankTableName, partId).Execute()
+				},
+				func(orgHandle string) (pipes.DatatankPart, *http.Response, error) {
+					return client.APIClient.OrgWorkspaceDatatankParts.Get(ctx, orgHandle, scope.workspace, datatankHandle, datatankTableName, partId).Execute()
+				},
+			)
+			if err != nil {
+				return resource.NonRetryableError(fmt.Errorf("%v", decodeResponse(r)))
+			}
+			processId = part.GetLastUpdateAttemptProcessId()
+			if processId == "" || processId == previousProcessIds[partId] {
+				return resource.RetryableError(fmt.Errorf("refresh of part %s has not started yet", partId))
+			}
+			return nil
+		})
+		if err != nil {
+			return diag.Errorf("error waiting for the refresh of part %s of datatank table %s to start: %v", partId, datatankTableName, err)
+		}
+
+		process, err := waitForWorkspaceProcess(ctx, client, scope, processId, time.Until(deadline))
+		if err != nil {
+			return diag.Errorf("error waiting for the refresh of part %s of datatank table %s: %v", partId, datatankTableName, err)
+		}
+		processIds = append(processIds, processId)
+		d.Set("process_ids", processIds)
+
+		if err = processRunError(process); err != nil {
+			return diag.Errorf("error refreshing part %s of datatank table %s: %v", partId, datatankTableName, err)
+		}
+	}
+
+	return nil
+}
+
+// A refresh cannot be read back once requested, the state recorded on create is kept as is
+func resourceWorkspaceDatatankTableRefreshRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// listDatatankParts:: List all parts of a datatank table in a user or organization workspace
+func listDatatankParts(ctx context.Context, client *PipesClient, scope workspaceScope, datatankHandle, datatankTableName string) ([]pipes.DatatankPart, *http.Response, error) {
+	var parts []pipes.DatatankPart
+	var nextToken string
+	for {
+		resp, r, err := workspaceScopeCall(ctx, client, scope,
+			func(userHandle string) (pipes.ListDatatankPartResponse, *http.Response, error) {
+				req := client.APIClient.UserWorkspaceDatatankParts.List(ctx, userHandle, scope.workspace, datatankHandle, datatankTableName)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			},
+			func(orgHandle string) (pipes.ListDatatankPartResponse, *http.Response, error) {
+				req := client.APIClient.OrgWorkspaceDatatankParts.List(ctx, orgHandle, scope.workspace, datatankHandle, datatankTableName)
+				if nextToken != "" {
+					req = req.NextToken(nextToken)
+				}
+				return req.Execute()
+			},
+		)
+		if err != nil {
+			return nil, r, err
+		}
+		if resp.Items != nil {
+			parts = append(parts, *resp.Items...)
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+	return parts, nil, nil
+}
@@ -0,0 +1,91 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// A table without parts has nothing to refresh, which is reported rather than recorded as a successful refresh
+func TestWorkspaceDatatankTableRefreshCreateNoParts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/org/myorg/workspace/myworkspace/datatank/fast_net/table/net_certificate/part" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"items": []}`)
+	})
+	d := schema.TestResourceDataRaw(t, resourceWorkspaceDatatankTableRefresh().Schema, map[string]interface{}{
+		"organization":     "myorg",
+		"workspace_handle": "myworkspace",
+		"datatank_handle":  "fast_net",
+		"name":             "net_certificate",
+	})
+
+	diags := resourceWorkspaceDatatankTableRefreshCreate(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "has no parts to refresh") {
+		t.Errorf("expected an error for a table without parts, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no refresh to be recorded, got ID %q", d.Id())
+	}
+}
+
+// test suites
+func TestAccUserWorkspaceDatatankTableRefresh_Basic(t *testing.T) {
+	resourceName := "pipes_workspace_datatank_table_refresh.refresh_net_certificate"
+	workspaceHandle := "workspace" + randomString(3)
+	datatankHandle := "fast_net"
+	name := "net_certificate"
+	frequency := `
+		{
+			"type": "interval",
+			"schedule": "daily"
+		}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWorkspaceDatatankTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspaceDatatankTableRefreshConfig(workspaceHandle, datatankHandle, name, frequency, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "part_ids.0"),
+					resource.TestCheckResourceAttrSet(resourceName, "process_ids.0"),
+					resource.TestCheckResourceAttrSet(resourceName, "refreshed_at"),
+				),
+			},
+			{
+				Config: testAccUserWorkspaceDatatankTableRefreshConfig(workspaceHandle, datatankHandle, name, frequency, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "triggers.source_version", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "process_ids.0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserWorkspaceDatatankTableRefreshConfig(workspaceHandle, datatankHandle, name, frequency, sourceVersion string) string {
+	return testAccUserWorkspaceDatatankTableConfig(workspaceHandle, datatankHandle, name, "table", "connection", "all_net", name, frequency) + fmt.Sprintf(`
+
+	resource "pipes_workspace_datatank_table_refresh" "refresh_net_certificate" {
+		workspace_handle    = pipes_workspace.test_workspace.handle
+		datatank_handle     = pipes_workspace_datatank.test_datatank_fast_net.handle
+		name                = pipes_workspace_datatank_table.test_datatank_table_net_certificate.name
+		wait_for_completion = true
+
+		triggers = {
+			source_version = "%s"
+		}
+	}`, sourceVersion)
+}
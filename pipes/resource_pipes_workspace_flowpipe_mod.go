@@ -2,11 +2,9 @@ package pipes
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,15 +13,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceFlowpipeModIdFormat = workspaceResourceIdFormat("<mod-alias>")
+
 func resourceWorkspaceFlowpipeMod() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceFlowpipeModInstall,
 		ReadContext:   resourceWorkspaceFlowpipeModRead,
 		UpdateContext: resourceWorkspaceFlowpipeModUpdate,
 		DeleteContext: resourceWorkspaceFlowpipeModUninstall,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceFlowpipeModIdFormat),
 		Schema: map[string]*schema.Schema{
 			"workspace_mod_id": {
 				Type:     schema.TypeString,
@@ -129,17 +127,15 @@ func resourceWorkspaceFlowpipeModInstall(ctx context.Context, d *schema.Resource
 
 	client := meta.(*PipesClient)
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionCreate. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceFlowpipeMods.Install(ctx, userHandle, workspaceHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceFlowpipeMods.Install(ctx, orgHandle, workspaceHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeMods.Install(ctx, userHandle, workspaceHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeMods.Install(ctx, orgHandle, workspaceHandle).Request(req).Execute()
+		},
+	)
 
 	// Check for errors
 	if err != nil {
@@ -167,56 +163,40 @@ func resourceWorkspaceFlowpipeModInstall(ctx context.Context, d *schema.Resource
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
 	d.Set("path", resp.Path)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If mod is installed for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	if isUser {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Alias))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Alias))
-	}
+	d.SetId(scope.id(*resp.Alias))
 
 	return diags
 }
 
 func resourceWorkspaceFlowpipeModRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, modAlias string
-	var isUser = false
 	var resp pipes.WorkspaceMod
-	var err error
 	var r *http.Response
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/modAlias" otherwise "workspaceHandle/modAlias"
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		isUser = true
-		workspaceHandle = parts[0]
-		modAlias = parts[1]
-	case 3:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		modAlias = parts[2]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<mod-alias> or <org-handle>/<workspace-handle>/<mod-alias>", d.Id())
+	id, err := workspaceFlowpipeModIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, modAlias := scope.workspace, id["mod-alias"]
 
 	client := meta.(*PipesClient)
 
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceFlowpipeModRead.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceFlowpipeMods.Get(ctx, userHandle, workspaceHandle, modAlias).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceFlowpipeMods.Get(ctx, orgHandle, workspaceHandle, modAlias).Execute()
-	}
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeMods.Get(ctx, userHandle, workspaceHandle, modAlias).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeMods.Get(ctx, orgHandle, workspaceHandle, modAlias).Execute()
+		},
+	)
 
 	// Check for errors
 	if err != nil {
@@ -243,16 +223,12 @@ func resourceWorkspaceFlowpipeModRead(ctx context.Context, d *schema.ResourceDat
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
 	d.Set("path", resp.Path)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If mod is installed for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	if isUser {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Alias))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Alias))
-	}
+	d.SetId(scope.id(*resp.Alias))
 
 	return diags
 }
@@ -274,17 +250,15 @@ func resourceWorkspaceFlowpipeModUpdate(ctx context.Context, d *schema.ResourceD
 
 	client := meta.(*PipesClient)
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceFlowpipeModUpdate.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceFlowpipeMods.Update(ctx, userHandle, workspaceHandle, modAlias).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceFlowpipeMods.Update(ctx, orgHandle, workspaceHandle, modAlias).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeMods.Update(ctx, userHandle, workspaceHandle, modAlias).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeMods.Update(ctx, orgHandle, workspaceHandle, modAlias).Request(req).Execute()
+		},
+	)
 
 	// Check for errors
 	if err != nil {
@@ -312,57 +286,41 @@ func resourceWorkspaceFlowpipeModUpdate(ctx context.Context, d *schema.ResourceD
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
 	d.Set("path", resp.Path)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If mod is installed for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	if isUser {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Alias))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Alias))
-	}
+	d.SetId(scope.id(*resp.Alias))
 
 	return diags
 }
 
 func resourceWorkspaceFlowpipeModUninstall(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, modAlias string
-	var isUser = false
-	var err error
 	var r *http.Response
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/modAlias" otherwise "workspaceHandle/modAlias"
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		isUser = true
-		workspaceHandle = parts[0]
-		modAlias = parts[1]
-	case 3:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		modAlias = parts[2]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<mod-alias> or <org-handle>/<workspace-handle>/<mod-alias>", d.Id())
+	id, err := workspaceFlowpipeModIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, modAlias := scope.workspace, id["mod-alias"]
 
 	log.Printf("\n[DEBUG] Uninstalling Flowpipe Mod: %s for Workspace: %s", modAlias, workspaceHandle)
 
 	client := meta.(*PipesClient)
 
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceFlowpipeModUninstall.getUserHandler error  %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspaceFlowpipeMods.Uninstall(ctx, userHandle, workspaceHandle, modAlias).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspaceFlowpipeMods.Uninstall(ctx, orgHandle, workspaceHandle, modAlias).Execute()
-	}
+	_, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeMods.Uninstall(ctx, userHandle, workspaceHandle, modAlias).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeMods.Uninstall(ctx, orgHandle, workspaceHandle, modAlias).Execute()
+		},
+	)
 
 	// Check for errors
 	if err != nil {
@@ -6,7 +6,6 @@ import (
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -16,15 +15,15 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceFlowpipeModVariableIdFormat = workspaceResourceIdFormat("<mod-alias>", "<variable-name>")
+
 func resourceWorkspaceFlowpipeModVariable() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceFlowpipeModVariableCreate,
 		ReadContext:   resourceWorkspaceFlowpipeModVariableRead,
 		UpdateContext: resourceWorkspaceFlowpipeModVariableUpdate,
 		DeleteContext: resourceWorkspaceFlowpipeModVariableDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceFlowpipeModVariableIdFormat),
 		Schema: map[string]*schema.Schema{
 			"workspace_mod_variable_id": {
 				Type:     schema.TypeString,
@@ -187,27 +186,17 @@ func resourceWorkspaceFlowpipeModVariableRead(ctx context.Context, d *schema.Res
 	var diags diag.Diagnostics
 	var orgHandle, workspaceHandle, modAlias, variableName string
 	var isUser = false
-	var err error
 	var r *http.Response
 	var resp pipes.WorkspaceModVariable
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/modAlias/variableName" otherwise "workspaceHandle/modAlias/variableName"
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 3:
-		isUser = true
-		workspaceHandle = parts[0]
-		modAlias = parts[1]
-		variableName = parts[2]
-	case 4:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		modAlias = parts[2]
-		variableName = parts[3]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<mod-alias> or <org-handle>/<workspace-handle>/<mod-alias>", d.Id())
+	id, err := workspaceFlowpipeModVariableIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, modAlias, variableName = id["org-handle"], id["workspace-handle"], id["mod-alias"], id["variable-name"]
+	isUser = orgHandle == ""
 
 	client := meta.(*PipesClient)
 
@@ -325,26 +314,16 @@ func resourceWorkspaceFlowpipeModVariableDelete(ctx context.Context, d *schema.R
 	var diags diag.Diagnostics
 	var orgHandle, workspaceHandle, modAlias, variableName string
 	var isUser = false
-	var err error
 	var r *http.Response
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/modAlias/variableName" otherwise "workspaceHandle/modAlias/variableName"
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 3:
-		isUser = true
-		workspaceHandle = parts[0]
-		modAlias = parts[1]
-		variableName = parts[2]
-	case 4:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		modAlias = parts[2]
-		variableName = parts[3]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<mod-alias> or <org-handle>/<workspace-handle>/<mod-alias>", d.Id())
+	id, err := workspaceFlowpipeModVariableIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, modAlias, variableName = id["org-handle"], id["workspace-handle"], id["mod-alias"], id["variable-name"]
+	isUser = orgHandle == ""
 
 	client := meta.(*PipesClient)
 
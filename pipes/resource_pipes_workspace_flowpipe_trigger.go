@@ -3,11 +3,9 @@ package pipes
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -16,15 +14,16 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceFlowpipeTriggerIdFormat = workspaceResourceIdFormat("<trigger-id>")
+
 func resourceWorkspaceFlowpipeTrigger() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceFlowpipeTriggerCreate,
 		ReadContext:   resourceWorkspaceFlowpipeTriggerRead,
 		UpdateContext: resourceWorkspaceFlowpipeTriggerUpdate,
 		DeleteContext: resourceWorkspaceFlowpipeTriggerDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		CustomizeDiff: scheduleCustomizeDiff("schedule", "trigger_schedule"),
+		Importer:      resourceIdImporter(workspaceFlowpipeTriggerIdFormat),
 		Schema: map[string]*schema.Schema{
 			"trigger_id": {
 				Type:     schema.TypeString,
@@ -62,10 +61,19 @@ func resourceWorkspaceFlowpipeTrigger() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"schedule": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsJSON,
+			"schedule":         scheduleJSONSchema("schedule", "trigger_schedule"),
+			"trigger_schedule": scheduleBlockSchema("schedule", "trigger_schedule"),
+			"next_run_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"query": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"args": {
 				Type:         schema.TypeString,
@@ -116,12 +124,11 @@ func resourceWorkspaceFlowpipeTriggerCreate(ctx context.Context, d *schema.Resou
 	var resp pipes.WorkspaceModTrigger
 	var err error
 
-	// parse frequency & args - return if error
-	var schedule pipes.PipelineFrequency
+	// parse schedule & args - return if error
 	var args map[string]interface{}
-	err = json.Unmarshal([]byte(d.Get("schedule").(string)), &schedule)
+	schedule, err := getPipelineFrequency(d, "schedule", "trigger_schedule")
 	if err != nil {
-		diags = append(diags, diag.Errorf("error parsing frequency for workspace Flowpipe trigger: %v", d.Get("frequency").(string))...)
+		diags = append(diags, diag.Errorf("error parsing schedule for workspace Flowpipe trigger: %v", err)...)
 	}
 	err = json.Unmarshal([]byte(d.Get("args").(string)), &args)
 	if err != nil {
@@ -166,17 +173,15 @@ func resourceWorkspaceFlowpipeTriggerCreate(ctx context.Context, d *schema.Resou
 	// Create client
 	client := meta.(*PipesClient)
 
-	var userHandle string
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceFlowpipeTriggerCreate.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceFlowpipeTriggers.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceFlowpipeTriggers.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceModTrigger, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeTriggers.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceModTrigger, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeTriggers.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -188,12 +193,18 @@ func resourceWorkspaceFlowpipeTriggerCreate(ctx context.Context, d *schema.Resou
 	d.Set("trigger_id", *resp.Id)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("workspace", workspaceHandle)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("title", resp.Title)
 	d.Set("description", resp.Description)
 	d.Set("name", resp.Name)
 	d.Set("pipeline", pipeline)
 	d.Set("schedule", FormatJson(resp.Schedule))
+	if resp.Schedule != nil {
+		d.Set("trigger_schedule", flattenPipelineFrequency(*resp.Schedule))
+	}
+	d.Set("next_run_at", resp.NextRunAt)
+	d.Set("type", resp.Type)
+	d.Set("query", resp.Query)
 	d.Set("args", FormatJson(resp.Args))
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -209,20 +220,13 @@ func resourceWorkspaceFlowpipeTriggerCreate(ctx context.Context, d *schema.Resou
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/triggerId" otherwise "workspaceHandle/triggerId"
-	if userHandle == "" {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Id))
-	}
+	d.SetId(scope.id(*resp.Id))
 
 	return diags
 }
 
 func resourceWorkspaceFlowpipeTriggerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	var orgHandle, userHandle, workspaceHandle, triggerNameOrId string
-	var isUser = false
-	var err error
 	var r *http.Response
 	var resp pipes.WorkspaceModTrigger
 
@@ -234,31 +238,23 @@ func resourceWorkspaceFlowpipeTriggerRead(ctx context.Context, d *schema.Resourc
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/triggerId" otherwise "workspaceHandle/triggerId"
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		isUser = true
-		workspaceHandle = parts[0]
-		triggerNameOrId = parts[1]
-	case 3:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		triggerNameOrId = parts[2]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<trigger-id> or <org-handle>/<workspace-handle>/<trigger-id>", d.Id())
+	id, err := workspaceFlowpipeTriggerIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, triggerNameOrId := scope.workspace, id["trigger-id"]
 
 	client := meta.(*PipesClient)
 
-	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceFlowpipeTriggerRead.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceFlowpipeTriggers.Get(ctx, userHandle, workspaceHandle, triggerNameOrId).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceFlowpipeTriggers.Get(ctx, orgHandle, workspaceHandle, triggerNameOrId).Execute()
-	}
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceModTrigger, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeTriggers.Get(ctx, userHandle, workspaceHandle, triggerNameOrId).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceModTrigger, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeTriggers.Get(ctx, orgHandle, workspaceHandle, triggerNameOrId).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -270,12 +266,18 @@ func resourceWorkspaceFlowpipeTriggerRead(ctx context.Context, d *schema.Resourc
 	d.Set("trigger_id", *resp.Id)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("workspace", workspaceHandle)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("title", resp.Title)
 	d.Set("description", resp.Description)
 	d.Set("name", resp.Name)
 	d.Set("pipeline", pipeline)
 	d.Set("schedule", FormatJson(resp.Schedule))
+	if resp.Schedule != nil {
+		d.Set("trigger_schedule", flattenPipelineFrequency(*resp.Schedule))
+	}
+	d.Set("next_run_at", resp.NextRunAt)
+	d.Set("type", resp.Type)
+	d.Set("query", resp.Query)
 	d.Set("args", FormatJson(resp.Args))
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -291,11 +293,7 @@ func resourceWorkspaceFlowpipeTriggerRead(ctx context.Context, d *schema.Resourc
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/triggerId" otherwise "workspaceHandle/triggerId"
-	if userHandle == "" {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Id))
-	}
+	d.SetId(scope.id(*resp.Id))
 
 	return diags
 }
@@ -312,12 +310,11 @@ func resourceWorkspaceFlowpipeTriggerUpdate(ctx context.Context, d *schema.Resou
 	}
 	pipeline := d.Get("pipeline").(string)
 
-	// parse frequency & args - return if error
-	var schedule pipes.PipelineFrequency
+	// parse schedule & args - return if error
 	var args map[string]interface{}
-	err = json.Unmarshal([]byte(d.Get("schedule").(string)), &schedule)
+	schedule, err := getPipelineFrequency(d, "schedule", "trigger_schedule")
 	if err != nil {
-		diags = append(diags, diag.Errorf("error parsing frequency for workspace Flowpipe trigger: %v", d.Get("frequency").(string))...)
+		diags = append(diags, diag.Errorf("error parsing schedule for workspace Flowpipe trigger: %v", err)...)
 	}
 	err = json.Unmarshal([]byte(d.Get("args").(string)), &args)
 	if err != nil {
@@ -361,17 +358,15 @@ func resourceWorkspaceFlowpipeTriggerUpdate(ctx context.Context, d *schema.Resou
 	// Create client
 	client := meta.(*PipesClient)
 
-	var userHandle string
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceFlowpipeTriggerUpdate.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceFlowpipeTriggers.Update(ctx, userHandle, workspaceHandle, triggerId).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceFlowpipeTriggers.Update(ctx, orgHandle, workspaceHandle, triggerId).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceModTrigger, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeTriggers.Update(ctx, userHandle, workspaceHandle, triggerId).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceModTrigger, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeTriggers.Update(ctx, orgHandle, workspaceHandle, triggerId).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -383,12 +378,18 @@ func resourceWorkspaceFlowpipeTriggerUpdate(ctx context.Context, d *schema.Resou
 	d.Set("trigger_id", *resp.Id)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("workspace", workspaceHandle)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("title", resp.Title)
 	d.Set("description", resp.Description)
 	d.Set("name", resp.Name)
 	d.Set("pipeline", pipeline)
 	d.Set("schedule", FormatJson(resp.Schedule))
+	if resp.Schedule != nil {
+		d.Set("trigger_schedule", flattenPipelineFrequency(*resp.Schedule))
+	}
+	d.Set("next_run_at", resp.NextRunAt)
+	d.Set("type", resp.Type)
+	d.Set("query", resp.Query)
 	d.Set("args", FormatJson(resp.Args))
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -404,11 +405,7 @@ func resourceWorkspaceFlowpipeTriggerUpdate(ctx context.Context, d *schema.Resou
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/triggerId" otherwise "workspaceHandle/triggerId"
-	if userHandle == "" {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Id))
-	}
+	d.SetId(scope.id(*resp.Id))
 
 	return diags
 }
@@ -417,38 +414,28 @@ func resourceWorkspaceFlowpipeTriggerDelete(ctx context.Context, d *schema.Resou
 	var diags diag.Diagnostics
 	var r *http.Response
 	var err error
-	var orgHandle, userHandle, workspaceHandle, triggerNameOrId string
-	var isUser = false
 
 	// If a trigger is created for a workspace inside an organization the id will be of the
 	// format "orgHandle/workspaceHandle/triggerId" otherwise "workspaceHandle/triggerId"
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		isUser = true
-		workspaceHandle = parts[0]
-		triggerNameOrId = parts[1]
-	case 3:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		triggerNameOrId = parts[2]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<trigger-id> or <org-handle>/<workspace-handle>/<trigger-id>", d.Id())
+	id, err := workspaceFlowpipeTriggerIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, triggerNameOrId := scope.workspace, id["trigger-id"]
 
 	log.Printf("\n[DEBUG] Deleting Trigger: %s for Workspace: %s", triggerNameOrId, workspaceHandle)
 
 	client := meta.(*PipesClient)
 
-	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceFlowpipeTriggerDelete.getUserHandler error  %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspaceFlowpipeTriggers.Delete(ctx, userHandle, workspaceHandle, triggerNameOrId).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspaceFlowpipeTriggers.Delete(ctx, orgHandle, workspaceHandle, triggerNameOrId).Execute()
-	}
+	_, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.UserWorkspaceFlowpipeTriggers.Delete(ctx, userHandle, workspaceHandle, triggerNameOrId).Execute()
+		},
+		func(orgHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceFlowpipeTriggers.Delete(ctx, orgHandle, workspaceHandle, triggerNameOrId).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
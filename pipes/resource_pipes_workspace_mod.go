@@ -2,11 +2,9 @@ package pipes
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,15 +12,15 @@ import (
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceModIdFormat = workspaceResourceIdFormat("<mod-alias>")
+
 func resourceWorkspaceMod() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceModInstall,
 		ReadContext:   resourceWorkspaceModRead,
 		UpdateContext: resourceWorkspaceModUpdate,
 		DeleteContext: resourceWorkspaceModUninstall,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceModIdFormat),
 		Schema: map[string]*schema.Schema{
 			"workspace_mod_id": {
 				Type:     schema.TypeString,
@@ -132,17 +130,15 @@ func resourceWorkspaceModInstall(ctx context.Context, d *schema.ResourceData, me
 	// Create request
 	req := pipes.CreateWorkspaceModRequest{Path: path, Constraint: &constraint}
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionCreate. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceMods.Install(ctx, userHandle, workspaceHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceMods.Install(ctx, orgHandle, workspaceHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceMods.Install(ctx, userHandle, workspaceHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceMods.Install(ctx, orgHandle, workspaceHandle).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -170,16 +166,12 @@ func resourceWorkspaceModInstall(ctx context.Context, d *schema.ResourceData, me
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
 	d.Set("path", resp.Path)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If mod is installed for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Alias))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Alias))
-	}
+	d.SetId(scope.id(*resp.Alias))
 
 	return diags
 }
@@ -189,45 +181,27 @@ func resourceWorkspaceModRead(ctx context.Context, d *schema.ResourceData, meta
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, modAlias string
-	var isUser = false
 
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(d.Id(), ":") {
-		separator = ":"
-	}
 	// If mod is installed for a workspace within an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	idParts := strings.Split(d.Id(), separator)
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<mod-alias>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		modAlias = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		modAlias = idParts[1]
+	id, err := workspaceModIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, modAlias := scope.workspace, id["mod-alias"]
 
 	var resp pipes.WorkspaceMod
-	var err error
 	var r *http.Response
 
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceModRead. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceMods.Get(ctx, userHandle, workspaceHandle, modAlias).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceMods.Get(ctx, orgHandle, workspaceHandle, modAlias).Execute()
-	}
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceMods.Get(ctx, userHandle, workspaceHandle, modAlias).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceMods.Get(ctx, orgHandle, workspaceHandle, modAlias).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -254,16 +228,12 @@ func resourceWorkspaceModRead(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
 	d.Set("path", resp.Path)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If mod is installed for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Alias))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Alias))
-	}
+	d.SetId(scope.id(*resp.Alias))
 
 	return diags
 }
@@ -284,17 +254,15 @@ func resourceWorkspaceModUpdate(ctx context.Context, d *schema.ResourceData, met
 	// Create request
 	req := pipes.UpdateWorkspaceModRequest{Constraint: &constraint}
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionCreate. getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceMods.Update(ctx, userHandle, workspaceHandle, modAlias).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceMods.Update(ctx, orgHandle, workspaceHandle, modAlias).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceMods.Update(ctx, userHandle, workspaceHandle, modAlias).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceMods.Update(ctx, orgHandle, workspaceHandle, modAlias).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -321,16 +289,12 @@ func resourceWorkspaceModUpdate(ctx context.Context, d *schema.ResourceData, met
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
 	d.Set("path", resp.Path)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If mod is installed for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, *resp.Alias))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, *resp.Alias))
-	}
+	d.SetId(scope.id(*resp.Alias))
 
 	return diags
 }
@@ -340,44 +304,26 @@ func resourceWorkspaceModUninstall(ctx context.Context, d *schema.ResourceData,
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, modAlias string
-	var isUser = false
 
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(d.Id(), ":") {
-		separator = ":"
-	}
-	idParts := strings.Split(d.Id(), separator)
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<mod-alias>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		modAlias = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		modAlias = idParts[1]
+	id, err := workspaceModIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, modAlias := scope.workspace, id["mod-alias"]
 
 	log.Printf("\n[DEBUG] Uninstalling mod: %s for workspace: %s", modAlias, workspaceHandle)
 
-	var err error
 	var r *http.Response
 
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceConnectionDelete. getUserHandler error: %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspaceMods.Uninstall(ctx, actorHandle, workspaceHandle, modAlias).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspaceMods.Uninstall(ctx, orgHandle, workspaceHandle, modAlias).Execute()
-	}
+	_, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.UserWorkspaceMods.Uninstall(ctx, userHandle, workspaceHandle, modAlias).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceMod, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceMods.Uninstall(ctx, orgHandle, workspaceHandle, modAlias).Execute()
+		},
+	)
 
 	if err != nil {
 		return diag.Errorf("error uninstalling mod: %v", decodeResponse(r))
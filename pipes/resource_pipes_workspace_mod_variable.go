@@ -6,7 +6,6 @@ import (
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,15 +14,15 @@ import (
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceModVariableIdFormat = workspaceResourceIdFormat("<mod-alias>", "<variable-name>")
+
 func resourceWorkspaceModVariable() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceModVariableCreateSetting,
 		ReadContext:   resourceWorkspaceModVariableRead,
 		UpdateContext: resourceWorkspaceModVariableUpdateSetting,
 		DeleteContext: resourceWorkspaceModVariableDeleteSetting,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceModVariableIdFormat),
 		Schema: map[string]*schema.Schema{
 			"workspace_mod_variable_id": {
 				Type:     schema.TypeString,
@@ -196,32 +195,16 @@ func resourceWorkspaceModVariableRead(ctx context.Context, d *schema.ResourceDat
 	var orgHandle, workspaceHandle, modAlias, variableName string
 	var isUser = false
 
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(d.Id(), ":") {
-		separator = ":"
-	}
 	// If mod is installed for a workspace within an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/ModAlias" otherwise "WorkspaceHandle/ModAlias"
-	idParts := strings.Split(d.Id(), separator)
-	if len(idParts) < 3 && len(idParts) > 4 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<mod-alias>/<name>", d.Id())
-	}
-
-	if len(idParts) == 4 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		modAlias = idParts[2]
-		variableName = idParts[3]
-	} else if len(idParts) == 3 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		modAlias = idParts[1]
-		variableName = idParts[2]
+	id, err := workspaceModVariableIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, modAlias, variableName = id["org-handle"], id["workspace-handle"], id["mod-alias"], id["variable-name"]
+	isUser = orgHandle == ""
 
 	var resp pipes.WorkspaceModVariable
-	var err error
 	var r *http.Response
 
 	if isUser {
@@ -350,31 +333,15 @@ func resourceWorkspaceModVariableDeleteSetting(ctx context.Context, d *schema.Re
 	var orgHandle, workspaceHandle, modAlias, variableName string
 	var isUser = false
 
-	// For backward-compatibility, we see whether the id contains : or /
-	separator := "/"
-	if strings.Contains(d.Id(), ":") {
-		separator = ":"
-	}
-	idParts := strings.Split(d.Id(), separator)
-	if len(idParts) < 3 && len(idParts) > 4 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<mod-alias>/<variable-name>", d.Id())
-	}
-
-	if len(idParts) == 4 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		modAlias = idParts[2]
-		variableName = idParts[3]
-	} else if len(idParts) == 3 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		modAlias = idParts[1]
-		variableName = idParts[2]
+	id, err := workspaceModVariableIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, modAlias, variableName = id["org-handle"], id["workspace-handle"], id["mod-alias"], id["variable-name"]
+	isUser = orgHandle == ""
 
 	log.Printf("\n[DEBUG] Setting deleted for variable: %s of mod: %s in workspace: %s", variableName, modAlias, workspaceHandle)
 
-	var err error
 	var r *http.Response
 
 	if isUser {
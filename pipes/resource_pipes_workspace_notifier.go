@@ -2,13 +2,11 @@ package pipes
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/turbot/go-kit/types"
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,15 +15,16 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceNotifierIdFormat = workspaceResourceIdFormat("<notifier-name>")
+
 func resourceWorkspaceNotifier() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceNotifierCreate,
 		ReadContext:   resourceWorkspaceNotifierRead,
 		UpdateContext: resourceWorkspaceNotifierUpdate,
 		DeleteContext: resourceWorkspaceNotifierDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		CustomizeDiff: notifierCustomizeDiff,
+		Importer:      resourceIdImporter(workspaceNotifierIdFormat, resolveWorkspaceNotifierId),
 		Schema: map[string]*schema.Schema{
 			"organization": {
 				Type:     schema.TypeString,
@@ -41,11 +40,10 @@ func resourceWorkspaceNotifier() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"notifies": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsJSON,
-			},
+			"notifies":            notifiesSchema(),
+			"notify":              notifySchema(),
+			"notifies_wo":         notifiesWriteOnlySchema(),
+			"notifies_wo_version": notifiesWriteOnlyVersionSchema(),
 			"state": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -121,13 +119,9 @@ func resourceWorkspaceNotifierCreate(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	client := meta.(*PipesClient)
@@ -162,7 +156,7 @@ func resourceWorkspaceNotifierCreate(ctx context.Context, d *schema.ResourceData
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -189,19 +183,12 @@ func resourceWorkspaceNotifierRead(ctx context.Context, d *schema.ResourceData,
 	var orgHandle, userHandle, workspaceHandle, notifierName string
 	var isUser = false
 
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		isUser = true
-		workspaceHandle = parts[0]
-		notifierName = parts[1]
-	case 3:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		notifierName = parts[2]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<notifier-name> or <org-handle>/<workspace-handle>/<notifier-name>", d.Id())
+	id, err := workspaceNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, notifierName = id["org-handle"], id["workspace-handle"], id["notifier-name"]
+	isUser = orgHandle == ""
 
 	client := meta.(*PipesClient)
 
@@ -227,7 +214,7 @@ func resourceWorkspaceNotifierRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -253,19 +240,12 @@ func resourceWorkspaceNotifierUpdate(ctx context.Context, d *schema.ResourceData
 	var orgHandle, userHandle, workspaceHandle, notifierName string
 	var isUser = false
 
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		isUser = true
-		workspaceHandle = parts[0]
-		notifierName = parts[1]
-	case 3:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		notifierName = parts[2]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<notifier-name> or <org-handle>/<workspace-handle>/<notifier-name>", d.Id())
+	id, err := workspaceNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, notifierName = id["org-handle"], id["workspace-handle"], id["notifier-name"]
+	isUser = orgHandle == ""
 
 	s := d.Get("state").(string)
 	state, err := pipes.NewNotifierStateFromValue(s)
@@ -273,13 +253,9 @@ func resourceWorkspaceNotifierUpdate(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("error parsing state for notifier: %v", err)
 	}
 
-	var notifies []map[string]interface{}
-	if v, ok := d.GetOk("notifies"); ok {
-		notifiesString := v.(string)
-		err = json.Unmarshal([]byte(notifiesString), &notifies)
-		if err != nil {
-			return diag.Errorf("error parsing notifies for notifier: %v", err)
-		}
+	notifies, err := getNotifies(d)
+	if err != nil {
+		return diag.Errorf("error parsing notifies for notifier: %v", err)
 	}
 
 	newNotifierName := notifierName
@@ -317,7 +293,7 @@ func resourceWorkspaceNotifierUpdate(ctx context.Context, d *schema.ResourceData
 	d.Set("tenant_id", resp.TenantId)
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("identity_id", resp.IdentityId)
-	d.Set("notifies", FormatJson(resp.Notifies))
+	setNotifies(d, resp.Notifies)
 	d.Set("precedence", resp.Precedence)
 	d.Set("state", resp.State)
 	d.Set("state_reason", resp.StateReason)
@@ -346,19 +322,12 @@ func resourceWorkspaceNotifierDelete(ctx context.Context, d *schema.ResourceData
 	var orgHandle, userHandle, workspaceHandle, notifierName string
 	var isUser = false
 
-	parts := strings.Split(d.Id(), "/")
-	switch len(parts) {
-	case 2:
-		isUser = true
-		workspaceHandle = parts[0]
-		notifierName = parts[1]
-	case 3:
-		orgHandle = parts[0]
-		workspaceHandle = parts[1]
-		notifierName = parts[2]
-	default:
-		return diag.Errorf("unexpected format for ID (%q), expected <workspace-handle>/<notifier-name> or <org-handle>/<workspace-handle>/<notifier-name>", d.Id())
+	id, err := workspaceNotifierIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, notifierName = id["org-handle"], id["workspace-handle"], id["notifier-name"]
+	isUser = orgHandle == ""
 
 	client := meta.(*PipesClient)
 
@@ -35,6 +35,27 @@ func TestAccUserWorkspaceNotifier_Basic(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"updated_at"},
 			},
+			{
+				Config: testAccUserWorkspaceNotifierNotifyBlockConfig(workspaceHandle, integrationHandle, notifierName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWorkspaceNotifierExists(workspaceHandle, notifierName),
+					resource.TestCheckResourceAttr(resourceName, "notify.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "notify.*", map[string]string{
+						"type":    "slack",
+						"channel": "alerts",
+					}),
+				),
+			},
+			{
+				Config: testAccUserWorkspaceNotifierWriteOnlyConfig(workspaceHandle, integrationHandle, notifierName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWorkspaceNotifierExists(workspaceHandle, notifierName),
+					resource.TestCheckResourceAttr(resourceName, "notifies", ""),
+					resource.TestCheckResourceAttr(resourceName, "notify.#", "0"),
+					resource.TestCheckNoResourceAttr(resourceName, "notifies_wo"),
+					resource.TestCheckResourceAttr(resourceName, "notifies_wo_version", "1"),
+				),
+			},
 		},
 	})
 }
@@ -58,6 +79,45 @@ resource "pipes_workspace_notifier" "slack_general" {
 `, integrationHandle, workspaceHandle, notifierName)
 }
 
+func testAccUserWorkspaceNotifierNotifyBlockConfig(workspaceHandle, integrationHandle, notifierName string) string {
+	return fmt.Sprintf(`
+data "pipes_tenant_integration" "slack" {
+	handle = "%s"
+}
+
+resource "pipes_workspace_notifier" "slack_general" {
+	workspace = "%s"
+	name = "%s"
+	notify {
+		type = "slack"
+		channel = "alerts"
+		integration = data.pipes_tenant_integration.slack.integration_id
+	}
+	state = "enabled"
+}
+`, integrationHandle, workspaceHandle, notifierName)
+}
+
+func testAccUserWorkspaceNotifierWriteOnlyConfig(workspaceHandle, integrationHandle, notifierName string) string {
+	return fmt.Sprintf(`
+data "pipes_tenant_integration" "slack" {
+	handle = "%s"
+}
+
+resource "pipes_workspace_notifier" "slack_general" {
+	workspace = "%s"
+	name = "%s"
+	notifies_wo = jsonencode([{
+		"type": "slack",
+		"channel": "general",
+		"integration": data.pipes_tenant_integration.slack.integration_id,
+    }])
+	notifies_wo_version = 1
+	state = "enabled"
+}
+`, integrationHandle, workspaceHandle, notifierName)
+}
+
 func testAccCheckWorkspaceNotifierExists(workspaceHandle, notifierName string) resource.TestCheckFunc {
 	ctx := context.Background()
 	return func(s *terraform.State) error {
@@ -2,12 +2,9 @@ package pipes
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,15 +12,16 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspacePipelineIdFormat = workspaceResourceIdFormat("<pipeline-id>")
+
 func resourceWorkspacePipeline() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspacePipelineCreate,
 		ReadContext:   resourceWorkspacePipelineRead,
 		UpdateContext: resourceWorkspacePipelineUpdate,
 		DeleteContext: resourceWorkspacePipelineDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		CustomizeDiff: scheduleCustomizeDiff("frequency", "schedule"),
+		Importer:      resourceIdImporter(workspacePipelineIdFormat),
 		Schema: map[string]*schema.Schema{
 			"workspace_pipeline_id": {
 				Type:     schema.TypeString,
@@ -39,10 +37,11 @@ func resourceWorkspacePipeline() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-			"frequency": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validation.StringIsJSON,
+			"frequency": scheduleJSONSchema("frequency", "schedule"),
+			"schedule":  scheduleBlockSchema("frequency", "schedule"),
+			"next_run_at": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"pipeline": {
 				Type:     schema.TypeString,
@@ -132,9 +131,9 @@ func resourceWorkspacePipelineCreate(ctx context.Context, d *schema.ResourceData
 	var desiredState string
 	tagsStr := "{}"
 
-	err = json.Unmarshal([]byte(d.Get("frequency").(string)), &frequency)
+	frequency, err = getPipelineFrequency(d, "frequency", "schedule")
 	if err != nil {
-		return diag.Errorf("error parsing frequency for workspace pipeline : %v", d.Get("frequency").(string))
+		return diag.Errorf("error parsing frequency for workspace pipeline : %v", err)
 	}
 	args, err := JSONStringToInterface(d.Get("args").(string))
 	if err != nil {
@@ -161,17 +160,15 @@ func resourceWorkspacePipelineCreate(ctx context.Context, d *schema.ResourceData
 		req.DesiredState = (*pipes.DesiredState)(&desiredState)
 	}
 
-	userHandle := ""
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspacePipelineCreate.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspacePipelines.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspacePipelines.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.UserWorkspacePipelines.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.OrgWorkspacePipelines.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -184,6 +181,8 @@ func resourceWorkspacePipelineCreate(ctx context.Context, d *schema.ResourceData
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("title", resp.Title)
 	d.Set("frequency", FormatJson(resp.Frequency))
+	d.Set("schedule", flattenPipelineFrequency(resp.Frequency))
+	d.Set("next_run_at", resp.NextRunAt)
 	d.Set("pipeline", resp.Pipeline)
 	d.Set("args", FormatJson(resp.Args))
 	if resp.Tags != nil {
@@ -202,16 +201,12 @@ func resourceWorkspacePipelineCreate(ctx context.Context, d *schema.ResourceData
 		d.Set("updated_by", resp.UpdatedBy.Handle)
 	}
 	d.Set("version_id", resp.VersionId)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace", workspaceHandle)
 
 	// If a pipeline is created for a workspace inside an organization then the ID will be of the
 	// format "OrganizationHandle/WorkspaceHandle/PipelineID" otherwise "WorkspaceHandle/PipelineID".
-	if userHandle == "" {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Id))
-	}
+	d.SetId(scope.id(resp.Id))
 
 	return diags
 }
@@ -221,40 +216,27 @@ func resourceWorkspacePipelineRead(ctx context.Context, d *schema.ResourceData,
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, pipelineId string
-	var isUser = false
 
 	// If a pipeline is created for a workspace inside an organization then the ID will be of the
 	// format "OrganizationHandle/WorkspaceHandle/PipelineID" otherwise "WorkspaceHandle/PipelineID".
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<pipeline-id>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		pipelineId = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		pipelineId = idParts[1]
+	id, err := workspacePipelineIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, pipelineId := scope.workspace, id["pipeline-id"]
 
 	var resp pipes.Pipeline
-	var err error
 	var r *http.Response
 
-	userHandle := ""
-	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspacePipelineRead.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspacePipelines.Get(ctx, userHandle, workspaceHandle, pipelineId).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspacePipelines.Get(ctx, orgHandle, workspaceHandle, pipelineId).Execute()
-	}
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.UserWorkspacePipelines.Get(ctx, userHandle, workspaceHandle, pipelineId).Execute()
+		},
+		func(orgHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.OrgWorkspacePipelines.Get(ctx, orgHandle, workspaceHandle, pipelineId).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -267,6 +249,8 @@ func resourceWorkspacePipelineRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("title", resp.Title)
 	d.Set("frequency", FormatJson(resp.Frequency))
+	d.Set("schedule", flattenPipelineFrequency(resp.Frequency))
+	d.Set("next_run_at", resp.NextRunAt)
 	d.Set("pipeline", resp.Pipeline)
 	d.Set("args", FormatJson(resp.Args))
 	if resp.Tags != nil {
@@ -285,16 +269,12 @@ func resourceWorkspacePipelineRead(ctx context.Context, d *schema.ResourceData,
 		d.Set("updated_by", resp.UpdatedBy.Handle)
 	}
 	d.Set("version_id", resp.VersionId)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace", workspaceHandle)
 
 	// If Pipeline is created for a Workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/PipelineID" otherwise "WorkspaceHandle/PipelineID"
-	if userHandle == "" {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Id))
-	}
+	d.SetId(scope.id(resp.Id))
 
 	return diags
 }
@@ -314,9 +294,9 @@ func resourceWorkspacePipelineUpdate(ctx context.Context, d *schema.ResourceData
 	pipelineId := d.Get("workspace_pipeline_id").(string)
 	title := d.Get("title").(string)
 	var frequency pipes.PipelineFrequency
-	err = json.Unmarshal([]byte(d.Get("frequency").(string)), &frequency)
+	frequency, err = getPipelineFrequency(d, "frequency", "schedule")
 	if err != nil {
-		return diag.Errorf("error parsing frequency for workspace pipeline : %v", d.Get("frequency").(string))
+		return diag.Errorf("error parsing frequency for workspace pipeline : %v", err)
 	}
 	args, err := JSONStringToInterface(d.Get("args").(string))
 	if err != nil {
@@ -343,17 +323,15 @@ func resourceWorkspacePipelineUpdate(ctx context.Context, d *schema.ResourceData
 		req.DesiredState = (*pipes.DesiredState)(&desiredState)
 	}
 
-	userHandle := ""
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspacePipelineUpdate.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspacePipelines.Update(ctx, userHandle, workspaceHandle, pipelineId).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspacePipelines.Update(ctx, orgHandle, workspaceHandle, pipelineId).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.UserWorkspacePipelines.Update(ctx, userHandle, workspaceHandle, pipelineId).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.OrgWorkspacePipelines.Update(ctx, orgHandle, workspaceHandle, pipelineId).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -366,6 +344,8 @@ func resourceWorkspacePipelineUpdate(ctx context.Context, d *schema.ResourceData
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("title", resp.Title)
 	d.Set("frequency", FormatJson(resp.Frequency))
+	d.Set("schedule", flattenPipelineFrequency(resp.Frequency))
+	d.Set("next_run_at", resp.NextRunAt)
 	d.Set("pipeline", resp.Pipeline)
 	d.Set("args", FormatJson(resp.Args))
 	if resp.Tags != nil {
@@ -384,16 +364,12 @@ func resourceWorkspacePipelineUpdate(ctx context.Context, d *schema.ResourceData
 		d.Set("updated_by", resp.UpdatedBy.Handle)
 	}
 	d.Set("version_id", resp.VersionId)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace", workspaceHandle)
 
 	// If Pipeline is created for a Workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/PipelineID" otherwise "WorkspaceHandle/PipelineID"
-	if userHandle == "" {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Id))
-	}
+	d.SetId(scope.id(resp.Id))
 
 	return diags
 }
@@ -403,39 +379,26 @@ func resourceWorkspacePipelineDelete(ctx context.Context, d *schema.ResourceData
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, pipelineId string
-	var isUser = false
 
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<pipeline-id>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		pipelineId = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		pipelineId = idParts[1]
+	id, err := workspacePipelineIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, pipelineId := scope.workspace, id["pipeline-id"]
 
 	log.Printf("\n[DEBUG] Deleting pipeline: %s for workspace: %s", pipelineId, workspaceHandle)
 
-	var err error
 	var r *http.Response
 
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspacePipelineDelete.getUserHandler error: %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspacePipelines.Delete(ctx, userHandle, workspaceHandle, pipelineId).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspacePipelines.Delete(ctx, orgHandle, workspaceHandle, pipelineId).Execute()
-	}
+	_, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.UserWorkspacePipelines.Delete(ctx, userHandle, workspaceHandle, pipelineId).Execute()
+		},
+		func(orgHandle string) (pipes.Pipeline, *http.Response, error) {
+			return client.APIClient.OrgWorkspacePipelines.Delete(ctx, orgHandle, workspaceHandle, pipelineId).Execute()
+		},
+	)
 
 	if err != nil {
 		return diag.Errorf("error deleting pipeline: %v", decodeResponse(r))
@@ -0,0 +1,92 @@
+package pipes
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func resourceWorkspacePipelineRun() *schema.Resource {
+	runSchema := processRunSchema()
+	runSchema["organization"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+	runSchema["workspace"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9]{1,23}$`), "Handle must be between 1 and 23 characters, and may only contain alphanumeric characters."),
+	}
+	runSchema["workspace_pipeline_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+	runSchema["args"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringIsJSON,
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceWorkspacePipelineRunCreate,
+		ReadContext:   resourceWorkspaceProcessRunRead,
+		UpdateContext: resourceWorkspaceProcessRunUpdate,
+		DeleteContext: resourceWorkspaceProcessRunDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: runSchema,
+	}
+}
+
+func resourceWorkspacePipelineRunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var r *http.Response
+	var err error
+	var resp pipes.PipelineCommandResponse
+
+	workspaceHandle := d.Get("workspace").(string)
+	pipelineId := d.Get("workspace_pipeline_id").(string)
+
+	req := pipes.PipelineCommandRequest{Command: pipes.PipelineCommandRun}
+	if value, ok := d.GetOk("args"); ok {
+		var args map[string]interface{}
+		if err = json.Unmarshal([]byte(value.(string)), &args); err != nil {
+			return diag.Errorf("error parsing args for workspace pipeline run: %v", err)
+		}
+		req.Args = &args
+	}
+
+	client := meta.(*PipesClient)
+
+	scope := workspaceScopeFromData(d, "workspace")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.PipelineCommandResponse, *http.Response, error) {
+			return client.APIClient.UserWorkspacePipelines.Command(ctx, userHandle, workspaceHandle, pipelineId).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.PipelineCommandResponse, *http.Response, error) {
+			return client.APIClient.OrgWorkspacePipelines.Command(ctx, orgHandle, workspaceHandle, pipelineId).Request(req).Execute()
+		},
+	)
+
+	// Error check
+	if err != nil {
+		return diag.Errorf("error running workspace pipeline %s: %v", pipelineId, decodeResponse(r))
+	}
+	log.Printf("\n[DEBUG] Process: %s started for Pipeline: %s on Workspace: %s", resp.ProcessId, pipelineId, workspaceHandle)
+
+	return startWorkspaceProcessRun(ctx, d, client, scope, resp.ProcessId)
+}
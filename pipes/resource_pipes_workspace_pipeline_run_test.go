@@ -0,0 +1,76 @@
+package pipes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// test suites
+func TestAccUserWorkspacePipelineRun_Basic(t *testing.T) {
+	resourceName := "pipes_workspace_pipeline_run.run_1"
+	workspaceHandle := "workspace" + randomString(3)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWorkspacePipelineDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspacePipelineRunConfig(workspaceHandle, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "process_id", regexp.MustCompile(`^p_[0-9a-v]{20}`)),
+					resource.TestCheckResourceAttr(resourceName, "process_type", "pipeline.command.run"),
+					resource.TestCheckResourceAttr(resourceName, "state", "completed"),
+				),
+			},
+			{
+				Config: testAccUserWorkspacePipelineRunConfig(workspaceHandle, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(resourceName, "process_id", regexp.MustCompile(`^p_[0-9a-v]{20}`)),
+					resource.TestCheckResourceAttr(resourceName, "triggers.run", "2"),
+					resource.TestCheckResourceAttr(resourceName, "state", "completed"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserWorkspacePipelineRunConfig(workspaceHandle, run string) string {
+	return fmt.Sprintf(`
+	resource "pipes_workspace" "test_workspace" {
+		handle = "%s"
+	}
+
+	resource "pipes_workspace_mod" "aws_compliance" {
+		workspace_handle = pipes_workspace.test_workspace.handle
+		path = "github.com/turbot/steampipe-mod-aws-compliance"
+	}
+
+	resource "pipes_workspace_pipeline" "pipeline_1" {
+		workspace = pipes_workspace.test_workspace.handle
+		title     = "Daily CIS Job"
+		pipeline  = "pipeline.snapshot_dashboard"
+		frequency = jsonencode({
+			"type": "interval",
+			"schedule": "daily"
+		})
+		args = jsonencode({
+			"resource": "aws_compliance.benchmark.cis_v140",
+			"inputs": {}
+		})
+
+		depends_on = [pipes_workspace_mod.aws_compliance]
+	}
+
+	resource "pipes_workspace_pipeline_run" "run_1" {
+		workspace             = pipes_workspace.test_workspace.handle
+		workspace_pipeline_id = pipes_workspace_pipeline.pipeline_1.workspace_pipeline_id
+
+		triggers = {
+			run = "%s"
+		}
+	}`, workspaceHandle, run)
+}
@@ -108,6 +108,16 @@ func TestAccUserWorkspacePipeline_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(processDataSourceName, "type", "pipeline.command.run"),
 				),
 			},
+			{
+				Config: testAccUserWorkspacePipelineScheduleConfig(workspaceHandle, title, pipeline, "0 9 * * MON-FRI", args, tags, mod),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWorkspacePipelineExists(workspaceHandle),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.type", "cron"),
+					resource.TestCheckResourceAttr(resourceName, "schedule.0.value", "0 9 * * MON-FRI"),
+					resource.TestCheckResourceAttrSet(resourceName, "next_run_at"),
+					TestJSONFieldEqual(t, resourceName, "frequency", `{"type": "cron", "schedule": "0 9 * * MON-FRI"}`),
+				),
+			},
 		},
 	})
 }
@@ -189,6 +199,32 @@ func testAccUserWorkspacePipelineProcessConfig(workspaceHandle, title, pipeline,
 	`, workspaceHandle, mod, title, pipeline, frequency, args, tags)
 }
 
+func testAccUserWorkspacePipelineScheduleConfig(workspaceHandle, title, pipeline, cron, args, tags, mod string) string {
+	return fmt.Sprintf(`
+	resource "pipes_workspace" "test_workspace" {
+		handle = "%s"
+	}
+
+	resource "pipes_workspace_mod" "aws_compliance" {
+		workspace_handle = pipes_workspace.test_workspace.handle
+		path = "%s"
+	}
+	
+	resource "pipes_workspace_pipeline" "pipeline_1" {
+		workspace = pipes_workspace.test_workspace.handle
+		title            = "%s"
+		pipeline         = "%s"
+		schedule {
+			type  = "cron"
+			value = "%s"
+		}
+		args             = jsonencode(%s)
+		tags             = jsonencode(%s)
+
+		depends_on = [pipes_workspace_mod.aws_compliance]
+	}`, workspaceHandle, mod, title, pipeline, cron, args, tags)
+}
+
 func testAccCheckWorkspacePipelineExists(workspaceHandle string) resource.TestCheckFunc {
 	ctx := context.Background()
 	return func(s *terraform.State) error {
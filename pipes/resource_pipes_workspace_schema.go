@@ -12,15 +12,15 @@ import (
 	pipes "github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceSchemaIdFormat = workspaceResourceIdFormat("<schema-handle>")
+
 func resourceWorkspaceSchema() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceSchemaCreate,
 		ReadContext:   resourceWorkspaceSchemaRead,
 		UpdateContext: resourceWorkspaceSchemaRead,
 		DeleteContext: resourceWorkspaceSchemaDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceSchemaIdFormat),
 		Schema: map[string]*schema.Schema{
 			"workspace_schema_id": {
 				Type:     schema.TypeString,
@@ -213,24 +213,15 @@ func resourceWorkspaceSchemaRead(ctx context.Context, d *schema.ResourceData, me
 	// ID formats
 	// User workspace schema - "WorkspaceHandle/SchemaHandle"
 	// Org workspace schema - "OrganizationHandle/WorkspaceHandle/SchemaHandle"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <org-handle>/<workspace-handle>/<schema-handle>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		schemaHandle = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		schemaHandle = idParts[1]
+	id, err := workspaceSchemaIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, schemaHandle = id["org-handle"], id["workspace-handle"], id["schema-handle"]
+	isUser = orgHandle == ""
 
 	var respSchema pipes.WorkspaceSchema
 	var respAssociation pipes.WorkspaceConn
-	var err error
 	var r *http.Response
 
 	if isUser {
@@ -378,24 +369,15 @@ func resourceWorkspaceSchemaDelete(ctx context.Context, d *schema.ResourceData,
 	// ID formats
 	// User workspace schema - "WorkspaceHandle/SchemaHandle"
 	// Org workspace schema - "OrganizationHandle/WorkspaceHandle/SchemaHandle"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <org-handle>/<workspace-handle>/<schema-handle>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		schemaHandle = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		schemaHandle = idParts[1]
+	id, err := workspaceSchemaIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	orgHandle, workspaceHandle, schemaHandle = id["org-handle"], id["workspace-handle"], id["schema-handle"]
+	isUser = orgHandle == ""
 
 	log.Printf("\n[DEBUG] Detaching Workspace schema: %s", fmt.Sprintf("%s/%s", workspaceHandle, schemaHandle))
 
-	var err error
 	var r *http.Response
 
 	if isUser {
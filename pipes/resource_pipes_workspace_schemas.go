@@ -0,0 +1,335 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func resourceWorkspaceSchemas() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkspaceSchemasUpdate,
+		ReadContext:   resourceWorkspaceSchemasRead,
+		UpdateContext: resourceWorkspaceSchemasUpdate,
+		DeleteContext: resourceWorkspaceSchemasDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceWorkspaceSchemasImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"workspace": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9]{1,23}$`), "Handle must be between 1 and 23 characters, and may only contain alphanumeric characters."),
+			},
+			"connection_handles": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"aggregator_handles": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connection_folder_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// workspaceSchemaSets:: The schemas of a workspace owned by the authoritative resource, by kind
+type workspaceSchemaSets struct {
+	connections map[string]bool
+	aggregators map[string]bool
+	folders     map[string]bool
+}
+
+func resourceWorkspaceSchemasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*PipesClient)
+
+	workspaceHandle := d.Get("workspace").(string)
+	isUser, actorHandle, r, err := getWorkspaceSchemasActor(ctx, d, client)
+	if err != nil {
+		return diag.Errorf("resourceWorkspaceSchemasRead.getUserHandler error  %v", decodeResponse(r))
+	}
+
+	current, r, err := listWorkspaceSchemaSets(ctx, client, isUser, actorHandle, workspaceHandle)
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Workspace (%s) not found", workspaceHandle),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error reading schemas of workspace %s: %v", workspaceHandle, decodeResponse(r))
+	}
+
+	resourceWorkspaceSchemasPopulateFromSets(d, current)
+
+	return diags
+}
+
+// Attach the schemas missing from the workspace and detach the ones which are not in the configuration
+func resourceWorkspaceSchemasUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	workspaceHandle := d.Get("workspace").(string)
+	isUser, actorHandle, r, err := getWorkspaceSchemasActor(ctx, d, client)
+	if err != nil {
+		return diag.Errorf("resourceWorkspaceSchemasUpdate.getUserHandler error  %v", decodeResponse(r))
+	}
+
+	current, r, err := listWorkspaceSchemaSets(ctx, client, isUser, actorHandle, workspaceHandle)
+	if err != nil {
+		return diag.Errorf("error reading schemas of workspace %s: %v", workspaceHandle, decodeResponse(r))
+	}
+	desired := workspaceSchemaSets{
+		connections: stringSetToMap(d.Get("connection_handles").(*schema.Set)),
+		aggregators: stringSetToMap(d.Get("aggregator_handles").(*schema.Set)),
+		folders:     stringSetToMap(d.Get("connection_folder_ids").(*schema.Set)),
+	}
+
+	// Detach first, so that a schema name freed by a detached connection can be reused by an attached aggregator
+	for _, extra := range [][]string{
+		missingKeys(current.connections, desired.connections),
+		missingKeys(current.aggregators, desired.aggregators),
+		missingKeys(current.folders, desired.folders),
+	} {
+		for _, name := range extra {
+			if r, err = detachWorkspaceSchema(ctx, client, isUser, actorHandle, workspaceHandle, name); err != nil {
+				return diag.Errorf("error detaching schema %s from workspace %s: %v", name, workspaceHandle, decodeResponse(r))
+			}
+		}
+	}
+
+	for _, handle := range missingKeys(desired.connections, current.connections) {
+		req := pipes.AttachWorkspaceSchemaRequest{}
+		req.SetConnectionHandle(handle)
+		if r, err = attachWorkspaceSchema(ctx, client, isUser, actorHandle, workspaceHandle, req); err != nil {
+			return diag.Errorf("error attaching connection %s to workspace %s: %v", handle, workspaceHandle, decodeResponse(r))
+		}
+	}
+	for _, handle := range missingKeys(desired.aggregators, current.aggregators) {
+		req := pipes.AttachWorkspaceSchemaRequest{}
+		req.SetAggregatorHandle(handle)
+		if r, err = attachWorkspaceSchema(ctx, client, isUser, actorHandle, workspaceHandle, req); err != nil {
+			return diag.Errorf("error attaching aggregator %s to workspace %s: %v", handle, workspaceHandle, decodeResponse(r))
+		}
+	}
+	for _, folderId := range missingKeys(desired.folders, current.folders) {
+		req := pipes.AttachWorkspaceSchemaRequest{}
+		req.SetConnectionFolder(folderId)
+		if r, err = attachWorkspaceSchema(ctx, client, isUser, actorHandle, workspaceHandle, req); err != nil {
+			return diag.Errorf("error attaching connection folder %s to workspace %s: %v", folderId, workspaceHandle, decodeResponse(r))
+		}
+	}
+
+	// If the workspace exists inside an Organization the id will be of the
+	// format "OrganizationHandle/WorkspaceHandle" otherwise "WorkspaceHandle"
+	if isUser {
+		d.SetId(workspaceHandle)
+	} else {
+		d.SetId(fmt.Sprintf("%s/%s", actorHandle, workspaceHandle))
+	}
+
+	return resourceWorkspaceSchemasRead(ctx, d, meta)
+}
+
+// Detach all the schemas owned by the resource
+func resourceWorkspaceSchemasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	workspaceHandle := d.Get("workspace").(string)
+	isUser, actorHandle, r, err := getWorkspaceSchemasActor(ctx, d, client)
+	if err != nil {
+		return diag.Errorf("resourceWorkspaceSchemasDelete.getUserHandler error  %v", decodeResponse(r))
+	}
+
+	var names []string
+	for _, key := range []string{"connection_handles", "aggregator_handles", "connection_folder_ids"} {
+		names = append(names, missingKeys(stringSetToMap(d.Get(key).(*schema.Set)), nil)...)
+	}
+	for _, name := range names {
+		r, err = detachWorkspaceSchema(ctx, client, isUser, actorHandle, workspaceHandle, name)
+		if err != nil && (r == nil || r.StatusCode != http.StatusNotFound) {
+			return diag.Errorf("error detaching schema %s from workspace %s: %v", name, workspaceHandle, decodeResponse(r))
+		}
+	}
+	d.SetId("")
+
+	return nil
+}
+
+// The schemas of a workspace inside an organization are imported using "OrganizationHandle/WorkspaceHandle" otherwise "WorkspaceHandle"
+func resourceWorkspaceSchemasImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := resolveResourceId(ctx, meta.(*PipesClient), workspaceIdFormat, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("organization", id["org-handle"])
+	d.Set("workspace", id["workspace-handle"])
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceWorkspaceSchemasPopulateFromSets(d *schema.ResourceData, sets workspaceSchemaSets) {
+	d.Set("connection_handles", missingKeys(sets.connections, nil))
+	d.Set("aggregator_handles", missingKeys(sets.aggregators, nil))
+	d.Set("connection_folder_ids", missingKeys(sets.folders, nil))
+}
+
+// getWorkspaceSchemasActor:: Get the handle of the user or organization owning the workspace
+func getWorkspaceSchemasActor(ctx context.Context, d *schema.ResourceData, client *PipesClient) (bool, string, *http.Response, error) {
+	isUser, orgHandle := isUserConnection(d)
+	if !isUser {
+		return false, orgHandle, nil, nil
+	}
+	userHandle, r, err := getUserHandler(ctx, client)
+	return true, userHandle, r, err
+}
+
+// listWorkspaceSchemaSets:: List the connections and aggregators attached to a workspace, and the connection folders associated with it.
+// Datatanks, discovered schemas and the connections attached through a folder are managed elsewhere and left out.
+func listWorkspaceSchemaSets(ctx context.Context, client *PipesClient, isUser bool, actorHandle, workspaceHandle string) (workspaceSchemaSets, *http.Response, error) {
+	sets := workspaceSchemaSets{connections: map[string]bool{}, aggregators: map[string]bool{}, folders: map[string]bool{}}
+
+	var nextToken string
+	for {
+		var resp pipes.ListWorkspaceSchemaResponse
+		var r *http.Response
+		var err error
+		if isUser {
+			req := client.APIClient.UserWorkspaceSchemas.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		} else {
+			req := client.APIClient.OrgWorkspaceSchemas.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return sets, r, err
+		}
+		for _, item := range resp.GetItems() {
+			switch item.GetType() {
+			case "connection":
+				sets.connections[item.Name] = true
+			case "aggregator":
+				sets.aggregators[item.Name] = true
+			}
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+
+	nextToken = ""
+	for {
+		var resp pipes.ListWorkspaceConnResponse
+		var r *http.Response
+		var err error
+		if isUser {
+			req := client.APIClient.UserWorkspaceConnectionAssociations.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		} else {
+			req := client.APIClient.OrgWorkspaceConnectionAssociations.List(ctx, actorHandle, workspaceHandle)
+			if nextToken != "" {
+				req = req.NextToken(nextToken)
+			}
+			resp, r, err = req.Execute()
+		}
+		if err != nil {
+			return sets, r, err
+		}
+		for _, item := range resp.GetItems() {
+			if (item.Connection != nil && isConnectionFolder(*item.Connection)) || strings.HasPrefix(item.ConnectionId, "f_") {
+				sets.folders[item.ConnectionId] = true
+			}
+		}
+		if resp.NextToken == nil || *resp.NextToken == "" {
+			break
+		}
+		nextToken = *resp.NextToken
+	}
+
+	log.Printf("\n[DEBUG] Workspace: %s has %d connections, %d aggregators and %d connection folders attached", workspaceHandle, len(sets.connections), len(sets.aggregators), len(sets.folders))
+	return sets, nil, nil
+}
+
+func attachWorkspaceSchema(ctx context.Context, client *PipesClient, isUser bool, actorHandle, workspaceHandle string, req pipes.AttachWorkspaceSchemaRequest) (*http.Response, error) {
+	var resp pipes.WorkspaceSchema
+	var r *http.Response
+	var err error
+	if isUser {
+		resp, r, err = client.APIClient.UserWorkspaceSchemas.Attach(ctx, actorHandle, workspaceHandle).Request(req).Execute()
+	} else {
+		resp, r, err = client.APIClient.OrgWorkspaceSchemas.Attach(ctx, actorHandle, workspaceHandle).Request(req).Execute()
+	}
+	if err == nil {
+		log.Printf("\n[DEBUG] Schema: %s attached to Workspace: %s", resp.Name, workspaceHandle)
+	}
+	return r, err
+}
+
+func detachWorkspaceSchema(ctx context.Context, client *PipesClient, isUser bool, actorHandle, workspaceHandle, schemaName string) (*http.Response, error) {
+	var r *http.Response
+	var err error
+	if isUser {
+		_, r, err = client.APIClient.UserWorkspaceSchemas.Detach(ctx, actorHandle, workspaceHandle, schemaName).Execute()
+	} else {
+		_, r, err = client.APIClient.OrgWorkspaceSchemas.Detach(ctx, actorHandle, workspaceHandle, schemaName).Execute()
+	}
+	if err == nil {
+		log.Printf("\n[DEBUG] Schema: %s detached from Workspace: %s", schemaName, workspaceHandle)
+	}
+	return r, err
+}
+
+func stringSetToMap(set *schema.Set) map[string]bool {
+	result := map[string]bool{}
+	for _, value := range set.List() {
+		result[value.(string)] = true
+	}
+	return result
+}
+
+// missingKeys:: The sorted keys of a which are not in b
+func missingKeys(a, b map[string]bool) []string {
+	result := []string{}
+	for key := range a {
+		if !b[key] {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,104 @@
+package pipes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// test suites
+func TestAccWorkspaceSchemas_Basic(t *testing.T) {
+	resourceName := "pipes_workspace_schemas.schemas"
+	orgHandle := "org" + randomString(5)
+	workspaceHandle := "workspace" + randomString(6)
+	connHandle1 := "aws" + randomString(5)
+	connHandle2 := "aws" + randomString(5)
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckOrganizationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkspaceSchemasConfig(orgHandle, workspaceHandle, connHandle1, connHandle2, `[pipes_organization_connection.connection_1.handle]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "connection_handles.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "connection_handles.*", connHandle1),
+					resource.TestCheckResourceAttr(resourceName, "organization", orgHandle),
+					resource.TestCheckResourceAttr(resourceName, "workspace", workspaceHandle),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccWorkspaceSchemasConfig(orgHandle, workspaceHandle, connHandle1, connHandle2, `[pipes_organization_connection.connection_2.handle]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "connection_handles.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "connection_handles.*", connHandle2),
+				),
+			},
+		},
+	})
+}
+
+// configs
+func testAccWorkspaceSchemasConfig(orgHandle, workspaceHandle, connHandle1, connHandle2, connections string) string {
+	return fmt.Sprintf(`
+resource "pipes_organization" "test_org" {
+	handle       = "%s"
+	display_name = "Terraform Test Org"
+}
+
+resource "pipes_workspace" "test_workspace" {
+	organization = pipes_organization.test_org.handle
+	handle       = "%s"
+}
+
+resource "pipes_organization_connection" "connection_1" {
+	organization = pipes_organization.test_org.handle
+	handle       = "%s"
+	plugin       = "aws"
+	config = jsonencode({
+		regions    = ["us-east-1"]
+		access_key = "redacted"
+		secret_key = "redacted"
+	})
+}
+
+resource "pipes_organization_connection" "connection_2" {
+	organization = pipes_organization.test_org.handle
+	handle       = "%s"
+	plugin       = "aws"
+	config = jsonencode({
+		regions    = ["us-east-1"]
+		access_key = "redacted"
+		secret_key = "redacted"
+	})
+}
+
+resource "pipes_organization_connection_permission" "permission_1" {
+	organization      = pipes_organization.test_org.handle
+	connection_handle = pipes_organization_connection.connection_1.handle
+	identity_handle   = pipes_organization.test_org.handle
+}
+
+resource "pipes_organization_connection_permission" "permission_2" {
+	organization      = pipes_organization.test_org.handle
+	connection_handle = pipes_organization_connection.connection_2.handle
+	identity_handle   = pipes_organization.test_org.handle
+}
+
+resource "pipes_workspace_schemas" "schemas" {
+	depends_on = [
+		pipes_organization_connection_permission.permission_1,
+		pipes_organization_connection_permission.permission_2,
+	]
+
+	organization       = pipes_organization.test_org.handle
+	workspace          = pipes_workspace.test_workspace.handle
+	connection_handles = %s
+}`, orgHandle, workspaceHandle, connHandle1, connHandle2, connections)
+}
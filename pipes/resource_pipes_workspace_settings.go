@@ -0,0 +1,231 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+func resourceWorkspaceSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWorkspaceSettingsUpdate,
+		ReadContext:   resourceWorkspaceSettingsRead,
+		UpdateContext: resourceWorkspaceSettingsUpdate,
+		DeleteContext: resourceWorkspaceSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceWorkspaceSettingsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"workspace": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z0-9]{1,23}$`), "Handle must be between 1 and 23 characters, and may only contain alphanumeric characters."),
+			},
+			"workspace_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// Search path settings of the Steampipe DB
+			"search_path_prefix": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+			"search_path": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// Metadata
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_by": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceWorkspaceSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*PipesClient)
+
+	workspaceHandle := d.Get("workspace").(string)
+	resp, r, err := getWorkspaceForSettings(ctx, d, client)
+	if err != nil {
+		if r != nil && r.StatusCode == http.StatusNotFound {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Workspace (%s) not found", workspaceHandle),
+			})
+			d.SetId("")
+			return diags
+		}
+		return diag.Errorf("error reading settings of workspace %s: %v", workspaceHandle, decodeResponse(r))
+	}
+
+	resourceWorkspaceSettingsPopulateFromResponse(d, resp)
+
+	return diags
+}
+
+func resourceWorkspaceSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*PipesClient)
+
+	workspaceHandle := d.Get("workspace").(string)
+	current, r, err := getWorkspaceForSettings(ctx, d, client)
+	if err != nil {
+		return diag.Errorf("error reading settings of workspace %s: %v", workspaceHandle, decodeResponse(r))
+	}
+
+	// Only send the settings which differ from the current settings of the workspace
+	req := pipes.UpdateWorkspaceRequest{}
+	changed := false
+	// On create the search path prefix is only sent when configured, so that a prefix already set on the workspace is kept.
+	// Once managed, it is cleared on the workspace when it is set to [] or removed from the configuration.
+	if (d.IsNewResource() && searchPathPrefixConfigured(d)) || (!d.IsNewResource() && d.HasChange("search_path_prefix")) {
+		list, err := convertToStringArray(d.Get("search_path_prefix").([]interface{}))
+		if err != nil {
+			return diag.Errorf("error converting search_path_prefix to string array: %v", err)
+		}
+		if strings.Join(current.GetSearchPathPrefix(), ",") != strings.Join(list, ",") {
+			req.SearchPathPrefix = &list
+			changed = true
+		}
+	}
+
+	resp := current
+	if changed {
+		isUser, orgHandle := isUserConnection(d)
+		if isUser {
+			var userHandle string
+			userHandle, r, err = getUserHandler(ctx, client)
+			if err != nil {
+				return diag.Errorf("resourceWorkspaceSettingsUpdate. getUserHandler error  %v", decodeResponse(r))
+			}
+			resp, r, err = client.APIClient.UserWorkspaces.Update(ctx, userHandle, workspaceHandle).Request(req).Execute()
+		} else {
+			resp, r, err = client.APIClient.OrgWorkspaces.Update(ctx, orgHandle, workspaceHandle).Request(req).Execute()
+		}
+		if err != nil {
+			return diag.Errorf("error updating settings of workspace %s: %v", workspaceHandle, decodeResponse(r))
+		}
+		log.Printf("\n[DEBUG] Settings updated for Workspace: %s", workspaceHandle)
+	}
+
+	resourceWorkspaceSettingsPopulateFromResponse(d, resp)
+
+	return nil
+}
+
+func resourceWorkspaceSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// No API to delete settings; they are intrinsic to the workspace.
+	// We leave current settings as-is and simply remove from state.
+	var diags diag.Diagnostics
+	d.SetId("")
+	return diags
+}
+
+// The settings of a workspace inside an organization are imported using "OrganizationHandle/WorkspaceHandle" otherwise "WorkspaceHandle"
+func resourceWorkspaceSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := resolveResourceId(ctx, meta.(*PipesClient), workspaceIdFormat, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.Set("organization", id["org-handle"])
+	d.Set("workspace", id["workspace-handle"])
+
+	// An imported search path prefix is managed from then on
+	resp, r, err := getWorkspaceForSettings(ctx, d, meta.(*PipesClient))
+	if err != nil {
+		return nil, fmt.Errorf("error reading settings of workspace %s: %v", id["workspace-handle"], decodeResponse(r))
+	}
+	d.Set("search_path_prefix", resp.GetSearchPathPrefix())
+	return []*schema.ResourceData{d}, nil
+}
+
+// searchPathPrefixConfigured:: Whether the search path prefix is set in the configuration, even to []
+func searchPathPrefixConfigured(d *schema.ResourceData) bool {
+	config := d.GetRawConfig()
+	return !config.IsNull() && !config.GetAttr("search_path_prefix").IsNull()
+}
+
+// searchPathPrefixManaged:: Whether the search path prefix is managed by the resource. The configuration is only available
+// on create and update, so on refresh the prefix kept in state is used instead.
+func searchPathPrefixManaged(d *schema.ResourceData) bool {
+	if searchPathPrefixConfigured(d) {
+		return true
+	}
+	state := d.GetRawState()
+	return !state.IsNull() && !state.GetAttr("search_path_prefix").IsNull()
+}
+
+// getWorkspaceForSettings:: Get the user or organization workspace the settings belong to
+func getWorkspaceForSettings(ctx context.Context, d *schema.ResourceData, client *PipesClient) (pipes.Workspace, *http.Response, error) {
+	workspaceHandle := d.Get("workspace").(string)
+	isUser, orgHandle := isUserConnection(d)
+	if isUser {
+		userHandle, r, err := getUserHandler(ctx, client)
+		if err != nil {
+			return pipes.Workspace{}, r, err
+		}
+		return client.APIClient.UserWorkspaces.Get(ctx, userHandle, workspaceHandle).Execute()
+	}
+	return client.APIClient.OrgWorkspaces.Get(ctx, orgHandle, workspaceHandle).Execute()
+}
+
+func resourceWorkspaceSettingsPopulateFromResponse(d *schema.ResourceData, resp pipes.Workspace) {
+	d.Set("workspace_id", resp.Id)
+
+	// search path, the prefix being left out of state until it is managed by the resource
+	if searchPathPrefixManaged(d) {
+		d.Set("search_path_prefix", resp.GetSearchPathPrefix())
+	}
+	if resp.SearchPath != nil {
+		d.Set("search_path", *resp.SearchPath)
+	}
+
+	// metadata
+	if resp.UpdatedAt != nil {
+		d.Set("updated_at", *resp.UpdatedAt)
+	}
+	if resp.UpdatedBy != nil {
+		d.Set("updated_by", resp.UpdatedBy.Handle)
+	}
+	d.Set("version_id", resp.VersionId)
+
+	// If the workspace exists inside an Organization the id will be of the
+	// format "OrganizationHandle/WorkspaceHandle" otherwise "WorkspaceHandle"
+	if orgHandle, ok := d.GetOk("organization"); ok {
+		d.SetId(fmt.Sprintf("%s/%s", orgHandle.(string), resp.Handle))
+	} else {
+		d.SetId(resp.Handle)
+	}
+}
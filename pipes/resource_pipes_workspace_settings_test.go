@@ -0,0 +1,92 @@
+package pipes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// A search path prefix already set on the workspace is kept when the resource is created without one
+func TestWorkspaceSettingsCreateWithoutSearchPathPrefix(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v0/org/myorg/workspace/myworkspace" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "w_123", "handle": "myworkspace", "search_path_prefix": ["net"], "version_id": 1}`)
+	})
+	d := schema.TestResourceDataRaw(t, resourceWorkspaceSettings().Schema, map[string]interface{}{
+		"organization": "myorg",
+		"workspace":    "myworkspace",
+	})
+	d.MarkNewResource()
+
+	diags := resourceWorkspaceSettingsUpdate(context.Background(), d, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error %v", diags)
+	}
+	if d.Id() != "myorg/myworkspace" {
+		t.Errorf("expected ID %q, got %q", "myorg/myworkspace", d.Id())
+	}
+	if prefix := d.Get("search_path_prefix").([]interface{}); len(prefix) != 0 {
+		t.Errorf("expected the unmanaged search path prefix to be left out of state, got %v", prefix)
+	}
+}
+
+// test suites
+func TestAccUserWorkspaceSettings_Basic(t *testing.T) {
+	resourceName := "pipes_workspace_settings.test_settings"
+	workspaceHandle := "workspace" + randomString(3)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserWorkspaceSettingsConfig(workspaceHandle, `["net"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "search_path_prefix.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "search_path_prefix.0", "net"),
+					resource.TestCheckResourceAttrPair(resourceName, "workspace_id", "pipes_workspace.test_workspace", "workspace_id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccUserWorkspaceSettingsConfig(workspaceHandle, `["net", "public"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "search_path_prefix.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "search_path_prefix.1", "public"),
+				),
+			},
+			{
+				Config: testAccUserWorkspaceSettingsConfig(workspaceHandle, `[]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "search_path_prefix.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserWorkspaceSettingsConfig(workspaceHandle, searchPathPrefix string) string {
+	return fmt.Sprintf(`
+	resource "pipes_workspace" "test_workspace" {
+		handle = "%s"
+	}
+
+	resource "pipes_workspace_settings" "test_settings" {
+		workspace          = pipes_workspace.test_workspace.handle
+		search_path_prefix = %s
+	}`, workspaceHandle, searchPathPrefix)
+}
@@ -15,15 +15,16 @@ import (
 	"github.com/turbot/pipes-sdk-go"
 )
 
+var workspaceSnapshotIdFormat = workspaceResourceIdFormat("<snapshot-id>")
+
 func resourceWorkspaceSnapshot() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceWorkspaceSnapshotCreate,
 		ReadContext:   resourceWorkspaceSnapshotRead,
 		UpdateContext: resourceWorkspaceSnapshotUpdate,
 		DeleteContext: resourceWorkspaceSnapshotDelete,
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Importer:      resourceIdImporter(workspaceSnapshotIdFormat),
+		CustomizeDiff: workspaceSnapshotCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"workspace_snapshot_id": {
 				Type:     schema.TypeString,
@@ -46,8 +47,13 @@ func resourceWorkspaceSnapshot() *schema.Resource {
 				Computed: true,
 			},
 			"visibility": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(pipes.SnapshotVisibilityWorkspace), string(pipes.SnapshotVisibilityAnyoneWithLink)}, false),
+			},
+			"url": {
 				Type:     schema.TypeString,
-				Optional: true,
 				Computed: true,
 			},
 			"dashboard_name": {
@@ -132,6 +138,7 @@ func resourceWorkspaceSnapshotCreate(ctx context.Context, d *schema.ResourceData
 	var r *http.Response
 	var resp pipes.WorkspaceSnapshot
 	var data pipes.WorkspaceSnapshotData
+	var actorHandle string
 
 	workspaceHandle := d.Get("workspace_handle").(string)
 	err = json.Unmarshal([]byte(d.Get("data").(string)), &data)
@@ -148,17 +155,17 @@ func resourceWorkspaceSnapshotCreate(ctx context.Context, d *schema.ResourceData
 	// Create request
 	req := pipes.CreateWorkspaceSnapshotRequest{Data: data, Tags: tags, Visibility: (*pipes.SnapshotVisibility)(&visibility)}
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceSnapshotCreate.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceSnapshots.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceSnapshots.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			actorHandle = userHandle
+			return client.APIClient.UserWorkspaceSnapshots.Create(ctx, userHandle, workspaceHandle).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			actorHandle = orgHandle
+			return client.APIClient.OrgWorkspaceSnapshots.Create(ctx, orgHandle, workspaceHandle).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -172,6 +179,7 @@ func resourceWorkspaceSnapshotCreate(ctx context.Context, d *schema.ResourceData
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("state", resp.State)
 	d.Set("visibility", resp.Visibility)
+	setWorkspaceSnapshotURL(d, client, scope.isUser(), actorHandle, workspaceHandle, resp)
 	d.Set("dashboard_name", resp.DashboardName)
 	d.Set("dashboard_title", resp.DashboardTitle)
 	d.Set("schema_version", resp.SchemaVersion)
@@ -187,16 +195,12 @@ func resourceWorkspaceSnapshotCreate(ctx context.Context, d *schema.ResourceData
 		d.Set("updated_by", resp.UpdatedBy.Handle)
 	}
 	d.Set("version_id", resp.VersionId)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If snapshot is created for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/SnapshotID" otherwise "WorkspaceHandle/SnapshotID"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Id))
-	}
+	d.SetId(scope.id(resp.Id))
 
 	return diags
 }
@@ -206,40 +210,30 @@ func resourceWorkspaceSnapshotRead(ctx context.Context, d *schema.ResourceData,
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, snapshotId string
-	var isUser = false
 
 	// If snapshot is created for a workspace within an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/SnapshotID" otherwise "WorkspaceHandle/SnapshotID"
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<snapshot-id>", d.Id())
-	}
-
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		snapshotId = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		snapshotId = idParts[1]
+	id, err := workspaceSnapshotIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, snapshotId := scope.workspace, id["snapshot-id"]
 
 	var resp pipes.WorkspaceSnapshot
-	var err error
 	var r *http.Response
+	var actorHandle string
 
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceSnapshotRead.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceSnapshots.Get(ctx, userHandle, workspaceHandle, snapshotId).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceSnapshots.Get(ctx, orgHandle, workspaceHandle, snapshotId).Execute()
-	}
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			actorHandle = userHandle
+			return client.APIClient.UserWorkspaceSnapshots.Get(ctx, userHandle, workspaceHandle, snapshotId).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			actorHandle = orgHandle
+			return client.APIClient.OrgWorkspaceSnapshots.Get(ctx, orgHandle, workspaceHandle, snapshotId).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -253,6 +247,7 @@ func resourceWorkspaceSnapshotRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("state", resp.State)
 	d.Set("visibility", resp.Visibility)
+	setWorkspaceSnapshotURL(d, client, scope.isUser(), actorHandle, workspaceHandle, resp)
 	d.Set("dashboard_name", resp.DashboardName)
 	d.Set("dashboard_title", resp.DashboardTitle)
 	d.Set("schema_version", resp.SchemaVersion)
@@ -268,16 +263,12 @@ func resourceWorkspaceSnapshotRead(ctx context.Context, d *schema.ResourceData,
 		d.Set("updated_by", resp.UpdatedBy.Handle)
 	}
 	d.Set("version_id", resp.VersionId)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If snapshot is created for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/SnapshotID" otherwise "WorkspaceHandle/SnapshotID"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Id))
-	}
+	d.SetId(scope.id(resp.Id))
 
 	return diags
 }
@@ -290,6 +281,7 @@ func resourceWorkspaceSnapshotUpdate(ctx context.Context, d *schema.ResourceData
 	var err error
 	var r *http.Response
 	var resp pipes.WorkspaceSnapshot
+	var actorHandle string
 
 	workspaceHandle := d.Get("workspace_handle").(string)
 	snapshotId := d.Get("workspace_snapshot_id").(string)
@@ -302,17 +294,17 @@ func resourceWorkspaceSnapshotUpdate(ctx context.Context, d *schema.ResourceData
 	// Create request
 	req := pipes.UpdateWorkspaceSnapshotRequest{Tags: tags, Visibility: (*pipes.SnapshotVisibility)(&visibility)}
 
-	isUser, orgHandle := isUserConnection(d)
-	if isUser {
-		var userHandle string
-		userHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceSnapshotUpdate.getUserHandler error  %v", decodeResponse(r))
-		}
-		resp, r, err = client.APIClient.UserWorkspaceSnapshots.Update(ctx, userHandle, workspaceHandle, snapshotId).Request(req).Execute()
-	} else {
-		resp, r, err = client.APIClient.OrgWorkspaceSnapshots.Update(ctx, orgHandle, workspaceHandle, snapshotId).Request(req).Execute()
-	}
+	scope := workspaceScopeFromData(d, "workspace_handle")
+	resp, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			actorHandle = userHandle
+			return client.APIClient.UserWorkspaceSnapshots.Update(ctx, userHandle, workspaceHandle, snapshotId).Request(req).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			actorHandle = orgHandle
+			return client.APIClient.OrgWorkspaceSnapshots.Update(ctx, orgHandle, workspaceHandle, snapshotId).Request(req).Execute()
+		},
+	)
 
 	// Error check
 	if err != nil {
@@ -326,6 +318,7 @@ func resourceWorkspaceSnapshotUpdate(ctx context.Context, d *schema.ResourceData
 	d.Set("workspace_id", resp.WorkspaceId)
 	d.Set("state", resp.State)
 	d.Set("visibility", resp.Visibility)
+	setWorkspaceSnapshotURL(d, client, scope.isUser(), actorHandle, workspaceHandle, resp)
 	d.Set("dashboard_name", resp.DashboardName)
 	d.Set("dashboard_title", resp.DashboardTitle)
 	d.Set("schema_version", resp.SchemaVersion)
@@ -341,16 +334,12 @@ func resourceWorkspaceSnapshotUpdate(ctx context.Context, d *schema.ResourceData
 		d.Set("updated_by", resp.UpdatedBy.Handle)
 	}
 	d.Set("version_id", resp.VersionId)
-	d.Set("organization", orgHandle)
+	d.Set("organization", scope.organization)
 	d.Set("workspace_handle", workspaceHandle)
 
 	// If snapshot is created for a workspace inside an Organization the id will be of the
 	// format "OrganizationHandle/WorkspaceHandle/SnapshotID" otherwise "WorkspaceHandle/SnapshotID"
-	if strings.HasPrefix(resp.IdentityId, "o_") {
-		d.SetId(fmt.Sprintf("%s/%s/%s", orgHandle, workspaceHandle, resp.Id))
-	} else {
-		d.SetId(fmt.Sprintf("%s/%s", workspaceHandle, resp.Id))
-	}
+	d.SetId(scope.id(resp.Id))
 
 	return diags
 }
@@ -360,39 +349,26 @@ func resourceWorkspaceSnapshotDelete(ctx context.Context, d *schema.ResourceData
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	var orgHandle, workspaceHandle, snapshotId string
-	var isUser = false
-
-	idParts := strings.Split(d.Id(), "/")
-	if len(idParts) < 2 && len(idParts) > 3 {
-		return diag.Errorf("unexpected format of ID (%q), expected <workspace-handle>/<snapshot-id>", d.Id())
-	}
 
-	if len(idParts) == 3 {
-		orgHandle = idParts[0]
-		workspaceHandle = idParts[1]
-		snapshotId = idParts[2]
-	} else if len(idParts) == 2 {
-		isUser = true
-		workspaceHandle = idParts[0]
-		snapshotId = idParts[1]
+	id, err := workspaceSnapshotIdFormat.parse(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	scope := workspaceScopeFromId(id)
+	workspaceHandle, snapshotId := scope.workspace, id["snapshot-id"]
 
 	log.Printf("\n[DEBUG] Deleting snapshot: %s for workspace: %s", snapshotId, workspaceHandle)
 
-	var err error
 	var r *http.Response
 
-	if isUser {
-		var actorHandle string
-		actorHandle, r, err = getUserHandler(ctx, client)
-		if err != nil {
-			return diag.Errorf("resourceWorkspaceSnapshotDelete.getUserHandler error: %v", decodeResponse(r))
-		}
-		_, r, err = client.APIClient.UserWorkspaceSnapshots.Delete(ctx, actorHandle, workspaceHandle, snapshotId).Execute()
-	} else {
-		_, r, err = client.APIClient.OrgWorkspaceSnapshots.Delete(ctx, orgHandle, workspaceHandle, snapshotId).Execute()
-	}
+	_, r, err = workspaceScopeCall(ctx, client, scope,
+		func(userHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			return client.APIClient.UserWorkspaceSnapshots.Delete(ctx, userHandle, workspaceHandle, snapshotId).Execute()
+		},
+		func(orgHandle string) (pipes.WorkspaceSnapshot, *http.Response, error) {
+			return client.APIClient.OrgWorkspaceSnapshots.Delete(ctx, orgHandle, workspaceHandle, snapshotId).Execute()
+		},
+	)
 
 	if err != nil {
 		return diag.Errorf("error deleting snapshot: %v", decodeResponse(r))
@@ -401,3 +377,42 @@ func resourceWorkspaceSnapshotDelete(ctx context.Context, d *schema.ResourceData
 
 	return diags
 }
+
+// setWorkspaceSnapshotURL:: Set the URL of the snapshot in the console, which requires to sign in. The public link of a
+// snapshot shared with anyone is built from a key which the API does not return, so it is not exposed. The URL is left
+// empty when the console URL is not known.
+func setWorkspaceSnapshotURL(d *schema.ResourceData, client *PipesClient, isUser bool, actorHandle, workspaceHandle string, resp pipes.WorkspaceSnapshot) {
+	var snapshotURL string
+	consoleURL := getConsoleURL(client)
+	if consoleURL != "" && isUser {
+		snapshotURL = fmt.Sprintf("%s/user/%s/workspace/%s/snapshot/%s", consoleURL, actorHandle, workspaceHandle, resp.Id)
+	} else if consoleURL != "" {
+		snapshotURL = fmt.Sprintf("%s/org/%s/workspace/%s/snapshot/%s", consoleURL, actorHandle, workspaceHandle, resp.Id)
+	}
+	d.Set("url", snapshotURL)
+}
+
+// workspaceSnapshotCustomizeDiff:: Fail the plan when the visibility of the snapshot is not permitted by the tenant
+func workspaceSnapshotCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	visibility, ok := d.GetOk("visibility")
+	if !ok || !d.HasChange("visibility") || !d.NewValueKnown("visibility") {
+		return nil
+	}
+
+	// The settings of the tenant are not readable by every actor, the API will validate the visibility in that case
+	client := meta.(*PipesClient)
+	settings, _, err := client.APIClient.Tenants.GetSettings(ctx).Execute()
+	if err != nil {
+		log.Printf("\n[WARN] Unable to read the permitted snapshot visibility of the tenant: %v", err)
+		return nil
+	}
+	if len(settings.WorkspaceSnapshotPermittedVisibility) == 0 {
+		return nil
+	}
+	for _, permitted := range settings.WorkspaceSnapshotPermittedVisibility {
+		if permitted == visibility.(string) {
+			return nil
+		}
+	}
+	return fmt.Errorf("visibility %q is not permitted for snapshots by the tenant, permitted values are: %s", visibility.(string), strings.Join(settings.WorkspaceSnapshotPermittedVisibility, ", "))
+}
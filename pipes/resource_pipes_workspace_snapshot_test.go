@@ -11,6 +11,27 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestGetConsoleURL(t *testing.T) {
+	t.Setenv("PIPES_HOST", "")
+	cases := map[string]string{
+		"https://pipes.turbot.com":           "https://pipes.turbot.com",
+		"https://mytenant.pipes.turbot.com/": "https://mytenant.pipes.turbot.com",
+		"http://localhost:8080/api/v0":       "http://localhost:8080",
+		"pipes.example.com":                  "",
+		"":                                   "",
+	}
+	for host, expected := range cases {
+		if consoleURL := getConsoleURL(&PipesClient{Config: &Config{Host: host}}); consoleURL != expected {
+			t.Errorf("%q: expected %q, got %q", host, expected, consoleURL)
+		}
+	}
+
+	t.Setenv("PIPES_HOST", "http://pipes.internal")
+	if consoleURL := getConsoleURL(&PipesClient{Config: &Config{}}); consoleURL != "http://pipes.internal" {
+		t.Errorf("expected the console URL to be resolved from PIPES_HOST, got %q", consoleURL)
+	}
+}
+
 // test suites
 func TestAccUserWorkspaceSnapshot_Basic(t *testing.T) {
 	resourceName := "pipes_workspace_snapshot.snapshot_1"
@@ -28,6 +49,7 @@ func TestAccUserWorkspaceSnapshot_Basic(t *testing.T) {
 					testAccCheckWorkspaceSnapshotExists(workspaceHandle),
 					resource.TestCheckResourceAttr(resourceName, "state", "available"),
 					resource.TestCheckResourceAttr(resourceName, "visibility", visibility),
+					resource.TestCheckResourceAttrSet(resourceName, "url"),
 				),
 			},
 			{
@@ -42,6 +64,7 @@ func TestAccUserWorkspaceSnapshot_Basic(t *testing.T) {
 					testAccCheckWorkspaceSnapshotExists(workspaceHandle),
 					resource.TestCheckResourceAttr(resourceName, "state", "available"),
 					resource.TestCheckResourceAttr(resourceName, "visibility", updatedVisibility),
+					resource.TestCheckResourceAttrSet(resourceName, "url"),
 				),
 			},
 		},
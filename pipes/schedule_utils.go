@@ -0,0 +1,241 @@
+package pipes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/turbot/pipes-sdk-go"
+)
+
+var scheduleTypes = []string{"interval", "cron"}
+
+var scheduleIntervals = []string{"hourly", "daily", "weekly", "monthly"}
+
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+// The five fields of a standard cron expression, names are indexed from min
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// scheduleJSONSchema:: The raw JSON form of a pipeline frequency, kept for backward compatibility
+func scheduleJSONSchema(jsonKey, blockKey string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: scheduleJSONStringsEqual,
+		ExactlyOneOf:     []string{jsonKey, blockKey},
+	}
+}
+
+// scheduleBlockSchema:: The structured form of a pipeline frequency
+func scheduleBlockSchema(jsonKey, blockKey string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		Computed:     true,
+		MaxItems:     1,
+		ExactlyOneOf: []string{jsonKey, blockKey},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(scheduleTypes, false),
+				},
+				"value": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+		},
+	}
+}
+
+// scheduleCustomizeDiff:: Validate at plan time the schedule set in the structured form, and mark the
+// other form and next_run_at as unknown when the schedule changes
+func scheduleCustomizeDiff(jsonKey, blockKey string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" {
+			if d.HasChange(blockKey) {
+				if err := d.SetNewComputed(jsonKey); err != nil {
+					return err
+				}
+			} else if d.HasChange(jsonKey) {
+				if err := d.SetNewComputed(blockKey); err != nil {
+					return err
+				}
+			}
+			if d.HasChanges(jsonKey, blockKey) {
+				if err := d.SetNewComputed("next_run_at"); err != nil {
+					return err
+				}
+			}
+		}
+
+		if d.NewValueKnown(blockKey) {
+			for _, item := range d.Get(blockKey).([]interface{}) {
+				if item == nil {
+					continue
+				}
+				block := item.(map[string]interface{})
+				if err := validateSchedule(block["type"].(string), block["value"].(string)); err != nil {
+					return fmt.Errorf("invalid %s: %v", blockKey, err)
+				}
+			}
+		}
+
+		// The JSON form is kept for backward compatibility, so it is left to the API to validate as before
+		return nil
+	}
+}
+
+// getPipelineFrequency:: Build the pipeline frequency for the API request from either the schedule block or the JSON
+func getPipelineFrequency(d *schema.ResourceData, jsonKey, blockKey string) (pipes.PipelineFrequency, error) {
+	var frequency pipes.PipelineFrequency
+
+	// both forms are computed, so use the config to find out which form the user has set
+	if block := d.GetRawConfig().GetAttr(blockKey); !block.IsNull() && block.LengthInt() > 0 {
+		item := d.Get(blockKey).([]interface{})[0].(map[string]interface{})
+		value := item["value"].(string)
+		frequency.Type = item["type"].(string)
+		frequency.Schedule = &value
+		return frequency, nil
+	}
+
+	if err := json.Unmarshal([]byte(d.Get(jsonKey).(string)), &frequency); err != nil {
+		return frequency, fmt.Errorf("error parsing %s: %v", jsonKey, err)
+	}
+	return frequency, nil
+}
+
+// flattenPipelineFrequency:: Convert the pipeline frequency returned by the API into a schedule block
+func flattenPipelineFrequency(frequency pipes.PipelineFrequency) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"type":  frequency.Type,
+			"value": frequency.GetSchedule(),
+		},
+	}
+}
+
+// schedule is a json string
+// apply standard formatting to old and new data then compare
+func scheduleJSONStringsEqual(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+	var oldFrequency, newFrequency pipes.PipelineFrequency
+	if err := json.Unmarshal([]byte(old), &oldFrequency); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newFrequency); err != nil {
+		return false
+	}
+	return oldFrequency.Type == newFrequency.Type && oldFrequency.GetSchedule() == newFrequency.GetSchedule()
+}
+
+// validateSchedule:: Check the schedule value is valid for its type
+func validateSchedule(scheduleType, value string) error {
+	switch scheduleType {
+	case "interval":
+		for _, interval := range scheduleIntervals {
+			if value == interval {
+				return nil
+			}
+		}
+		return fmt.Errorf("interval %q must be one of %s", value, strings.Join(scheduleIntervals, ", "))
+	case "cron":
+		return validateCronExpression(value)
+	default:
+		return fmt.Errorf("type %q must be one of %s", scheduleType, strings.Join(scheduleTypes, ", "))
+	}
+}
+
+// validateCronExpression:: Check the value is a standard five field cron expression or a cron macro
+func validateCronExpression(expression string) error {
+	if strings.HasPrefix(expression, "@") {
+		for _, macro := range cronMacros {
+			if expression == macro {
+				return nil
+			}
+		}
+		return fmt.Errorf("cron expression %q is not a supported macro, expected one of %s", expression, strings.Join(cronMacros, ", "))
+	}
+
+	parts := strings.Fields(expression)
+	if len(parts) != len(cronFields) {
+		return fmt.Errorf("cron expression %q must have %d fields (minute hour day-of-month month day-of-week), got %d", expression, len(cronFields), len(parts))
+	}
+	for i, part := range parts {
+		if err := cronFields[i].validate(part); err != nil {
+			return fmt.Errorf("cron expression %q: %v", expression, err)
+		}
+	}
+	return nil
+}
+
+// validate:: Check a comma separated list of values, ranges and steps against the bounds of the field
+func (f cronField) validate(part string) error {
+	for _, item := range strings.Split(part, ",") {
+		rangePart, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q in %s field", step, f.name)
+			}
+		}
+		if rangePart == "*" {
+			continue
+		}
+		start, end, isRange := strings.Cut(rangePart, "-")
+		low, err := f.parse(start)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		high, err := f.parse(end)
+		if err != nil {
+			return err
+		}
+		if low > high {
+			return fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+		}
+	}
+	return nil
+}
+
+// parse:: Convert a single number or name of the field into its value
+func (f cronField) parse(value string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(value, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", value, f.name, f.min, f.max)
+	}
+	return n, nil
+}
@@ -0,0 +1,74 @@
+package pipes
+
+import (
+	"testing"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	cases := map[string]struct {
+		scheduleType string
+		value        string
+		valid        bool
+	}{
+		"hourly":           {"interval", "hourly", true},
+		"daily":            {"interval", "daily", true},
+		"weekly":           {"interval", "weekly", true},
+		"monthly":          {"interval", "monthly", true},
+		"duration":         {"interval", "15m", false},
+		"uppercase":        {"interval", "Daily", false},
+		"empty interval":   {"interval", "", false},
+		"cron":             {"cron", "0 9 * * MON-FRI", true},
+		"invalid cron":     {"cron", "0 9 * *", false},
+		"interval as cron": {"cron", "daily", false},
+		"manual":           {"manual", "", false},
+		"unknown type":     {"every", "daily", false},
+		"empty type":       {"", "daily", false},
+	}
+	for name, c := range cases {
+		err := validateSchedule(c.scheduleType, c.value)
+		if c.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("%s: expected %q of type %q to be rejected", name, c.value, c.scheduleType)
+		}
+	}
+}
+
+func TestValidateCronExpression(t *testing.T) {
+	cases := map[string]bool{
+		"* * * * *":          true,
+		"0 9 * * MON-FRI":    true,
+		"*/15 * * * *":       true,
+		"0 0 1,15 * *":       true,
+		"0-30/10 8-18 * * *": true,
+		"0 12 * JAN-MAR sun": true,
+		"0 0 * * 7":          true,
+		"59 23 31 12 6":      true,
+		"@daily":             true,
+		"@hourly":            true,
+		"@annually":          true,
+		"@every 5m":          false,
+		"@reboot":            false,
+		"":                   false,
+		"* * * *":            false,
+		"* * * * * *":        false,
+		"60 * * * *":         false,
+		"* 24 * * *":         false,
+		"* * 0 * *":          false,
+		"* * * 13 *":         false,
+		"* * * * 8":          false,
+		"30-10 * * * *":      false,
+		"*/0 * * * *":        false,
+		"*/x * * * *":        false,
+		"* * * FOO *":        false,
+		"1,,2 * * * *":       false,
+	}
+	for expression, valid := range cases {
+		err := validateCronExpression(expression)
+		if valid && err != nil {
+			t.Errorf("%q: unexpected error %v", expression, err)
+		} else if !valid && err == nil {
+			t.Errorf("%q: expected the cron expression to be rejected", expression)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package pipes
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// workspaceScope:: The workspace a resource belongs to, and whether the workspace belongs to the user of the token or to
+// an organization. The scope resolves the actor the API is called for, picks the user or organization API service, and
+// builds and parses the IDs of the resources of the workspace, i.e. "OrganizationHandle/WorkspaceHandle/..." in the
+// workspace of an organization, and "WorkspaceHandle/..." in a workspace of the user.
+type workspaceScope struct {
+	// The handle of the organization, empty for a workspace of the user
+	organization string
+	workspace    string
+}
+
+// workspaceScopeFromData:: The scope of a resource from its organization and the given workspace handle attribute
+func workspaceScopeFromData(d *schema.ResourceData, workspaceAttribute string) workspaceScope {
+	_, orgHandle := isUserConnection(d)
+	return workspaceScope{
+		organization: orgHandle,
+		workspace:    d.Get(workspaceAttribute).(string),
+	}
+}
+
+func (s workspaceScope) isUser() bool {
+	return s.organization == ""
+}
+
+// id:: The ID of a resource of the workspace, from the parts identifying the resource within the workspace. The ID of
+// the workspace itself has no parts.
+func (s workspaceScope) id(parts ...string) string {
+	parts = append([]string{s.workspace}, parts...)
+	if !s.isUser() {
+		parts = append([]string{s.organization}, parts...)
+	}
+	return strings.Join(parts, "/")
+}
+
+// resourceId:: The parsed ID of a resource of the workspace, e.g. of a resource listed in the workspace, from the names
+// and values of the parts identifying the resource within the workspace. The ID of the workspace itself has no parts.
+func (s workspaceScope) resourceId(parts ...string) resourceId {
+	id := resourceId{"workspace-handle": s.workspace}
+	if !s.isUser() {
+		id["org-handle"] = s.organization
+	}
+	for i := 0; i+1 < len(parts); i += 2 {
+		id[parts[i]] = parts[i+1]
+	}
+	return id
+}
+
+// workspaceScopeFromId:: The scope of a resource from its ID, parsed with a workspaceResourceIdFormat
+func workspaceScopeFromId(id resourceId) workspaceScope {
+	return workspaceScope{
+		organization: id["org-handle"],
+		workspace:    id["workspace-handle"],
+	}
+}
+
+// workspaceScopeCall:: Call the user or the organization API service of a workspace resource, depending on its scope. The
+// user service is called with the handle of the actor, and the organization service with the handle of the organization.
+func workspaceScopeCall[T any](ctx context.Context, client *PipesClient, scope workspaceScope, user func(userHandle string) (T, *http.Response, error), org func(orgHandle string) (T, *http.Response, error)) (T, *http.Response, error) {
+	if !scope.isUser() {
+		return org(scope.organization)
+	}
+	userHandle, r, err := getUserHandler(ctx, client)
+	if err != nil {
+		var resp T
+		return resp, r, err
+	}
+	return user(userHandle)
+}
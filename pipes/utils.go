@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"net/url"
+	"os"
 	"strconv"
 	"testing"
 
@@ -73,6 +75,20 @@ func getWorkspaceDetails(ctx context.Context, client *PipesClient, d *schema.Res
 	return &resp, r, nil
 }
 
+// getConsoleURL:: The URL of the Turbot Pipes console, resolved from the host the client was configured with. Empty
+// when no host is configured, as the console of the default API host is not known to the provider.
+func getConsoleURL(client *PipesClient) string {
+	host := client.Config.Host
+	if host == "" {
+		host = os.Getenv("PIPES_HOST")
+	}
+	parsedURL, err := url.Parse(host)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s", parsedURL.Scheme, parsedURL.Host)
+}
+
 // Decode response body
 func decodeResponse(r *http.Response) string {
 	var errBody interface{}
@@ -212,3 +228,20 @@ func FormatIntegrationJSONString(body string) (string, map[string]interface{}) {
 	}
 	return body, data
 }
+
+// convert a map of tags returned by the API into a map of strings so it can be
+// stored in a schema.TypeMap; non-string values are stored as JSON
+func tagsToStringMap(tags *map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	if tags == nil {
+		return result
+	}
+	for key, value := range *tags {
+		if s, ok := value.(string); ok {
+			result[key] = s
+		} else {
+			result[key] = FormatJson(value)
+		}
+	}
+	return result
+}